@@ -0,0 +1,209 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// delimiters used by the server in block mode
+const (
+	blockStart     = 0x15
+	blockSeparator = 0x16
+	blockEnd       = 0x17
+	blockPoseStart = 0x18
+	blockPoseEnd   = 0x19
+)
+
+// command codes the server replies with when a command fails in block mode
+const (
+	BlockErrorBadCommand = 512 + iota
+	BlockErrorBadParams
+	BlockErrorAmbiguous
+	BlockErrorRights
+	BlockErrorObsolete
+	BlockErrorRemoved
+	BlockErrorNotPlaying
+	BlockErrorNoSequence
+	BlockErrorLength
+)
+
+// the largest command identifier before identifiers wrap around
+const maxBlockID = 1 << 16
+
+// blocks correlates commands sent in block mode with their replies
+type blocks struct {
+	sync.Mutex
+	nextID  uint32
+	pending map[uint32]chan *Response
+	// an incomplete block carried over to the next read
+	partial []byte
+}
+
+// register assigns an identifier to a new command. If ch is not nil, the
+// reply to the command is delivered on it instead of the event stream
+func (b *blocks) register(ch chan *Response) uint32 {
+	b.Lock()
+	defer b.Unlock()
+	if b.pending == nil {
+		b.pending = make(map[uint32]chan *Response)
+	}
+	for {
+		b.nextID = b.nextID%maxBlockID + 1
+		if _, ok := b.pending[b.nextID]; !ok {
+			break
+		}
+	}
+	if ch != nil {
+		b.pending[b.nextID] = ch
+	}
+	return b.nextID
+}
+
+// cancel stops waiting for the reply to the given command
+func (b *blocks) cancel(id uint32) {
+	b.Lock()
+	defer b.Unlock()
+	delete(b.pending, id)
+}
+
+// deliver hands the response to the caller waiting on it, if any
+func (b *blocks) deliver(resp *Response) bool {
+	b.Lock()
+	ch, ok := b.pending[resp.Id]
+	delete(b.pending, resp.Id)
+	b.Unlock()
+	if ok {
+		ch <- resp
+	}
+	return ok
+}
+
+// split separates the replies to commands from unsolicited server output
+func (b *blocks) split(msg []byte) ([]*Response, []byte) {
+	b.Lock()
+	if len(b.partial) > 0 {
+		msg = append(b.partial, msg...)
+		b.partial = nil
+	}
+	b.Unlock()
+
+	var resps []*Response
+	var rest []byte
+	for {
+		i := bytes.IndexByte(msg, blockStart)
+		if i == -1 {
+			rest = append(rest, msg...)
+			break
+		}
+		rest = append(rest, msg[:i]...)
+
+		j := bytes.IndexByte(msg[i:], blockEnd)
+		if j == -1 {
+			b.Lock()
+			b.partial = append([]byte{}, msg[i:]...)
+			b.Unlock()
+			break
+		}
+
+		if resp := parseBlock(msg[i+1 : i+j]); resp != nil {
+			resps = append(resps, resp)
+		}
+		msg = msg[i+j+1:]
+	}
+
+	rest = bytes.Replace(rest, []byte{blockPoseStart}, []byte{}, -1)
+	rest = bytes.Replace(rest, []byte{blockPoseEnd}, []byte{}, -1)
+	return resps, bytes.TrimSpace(rest)
+}
+
+// parseBlock parses the contents of a block, id BLK_SEPARATOR code BLK_SEPARATOR body
+func parseBlock(b []byte) *Response {
+	parts := bytes.SplitN(b, []byte{blockSeparator}, 3)
+	if len(parts) != 3 {
+		return nil
+	}
+
+	id, err := strconv.ParseUint(string(parts[0]), 10, 32)
+	if err != nil {
+		return nil
+	}
+
+	code, err := strconv.ParseUint(string(parts[1]), 10, 32)
+	if err != nil {
+		return nil
+	}
+
+	body := bytes.Replace(parts[2], []byte{blockPoseStart}, []byte{}, -1)
+	body = bytes.Replace(body, []byte{blockPoseEnd}, []byte{}, -1)
+	return &Response{
+		Id:   uint32(id),
+		Code: uint32(code),
+		Body: string(bytes.TrimSpace(body)),
+	}
+}
+
+// decode decodes server output, routing replies to commands issued with Do
+// to their callers. Replies to commands sent with Send, and all unsolicited
+// output, are decoded into messages
+func (b *blocks) decode(msg []byte) []interface{} {
+	resps, rest := b.split(msg)
+	var msgs []interface{}
+	for _, resp := range resps {
+		if !b.deliver(resp) {
+			msgs = append(msgs, decodeMessages([]byte(resp.Body))...)
+		}
+	}
+	return append(msgs, decodeMessages(rest)...)
+}
+
+// Do sends a command to the ICS server and waits for its reply. It requires
+// block mode, enabled with Config.BlockMode or Config.Ivariables, and the
+// client must be receiving messages concurrently, for instance through Run.
+// An error is returned alongside the response if the server rejected the
+// command
+func (client *Client) Do(ctx context.Context, cmd string) (*Response, error) {
+	if !client.hasFeature("block") {
+		return nil, errors.New("block mode is not enabled")
+	}
+
+	ch := make(chan *Response, 1)
+	client.session.record(cmd)
+	id, err := client.write(client.getConn(), []byte(cmd), ch)
+	if err != nil {
+		client.blocks.cancel(id)
+		return nil, err
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.Code >= BlockErrorBadCommand {
+			return resp, errors.Errorf("command %q failed with code %d: %s", cmd, resp.Code, resp.Body)
+		}
+		return resp, nil
+	case <-ctx.Done():
+		client.blocks.cancel(id)
+		return nil, ctx.Err()
+	}
+}
+
+// write sends a command on the given connection. In block mode, the command
+// is tagged with a new identifier, which is returned, and its reply is
+// delivered on ch if it is not nil
+func (client *Client) write(conn *Conn, msg []byte, ch chan *Response) (uint32, error) {
+	p := commandPriority(msg)
+	var id uint32
+	if client.hasFeature("block") {
+		id = client.blocks.register(ch)
+		msg = append([]byte(strconv.FormatUint(uint64(id), 10)+" "), msg...)
+	}
+	return id, writeCommand(conn, client.config, msg, p)
+}
@@ -0,0 +1,161 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"log"
+	"sync"
+)
+
+// Bughouse links the two boards of a bughouse game: the game we are playing
+// (or observing) and the game of our partner
+type Bughouse struct {
+	mu          sync.Mutex
+	client      *Client
+	game        uint32
+	partnerGame uint32
+	// whether we asked the server to observe the partner game
+	observing bool
+	ended     bool
+	// latest positions and holdings of both boards, ours first
+	boards   [2]*GameMove
+	holdings [2]*Holdings
+	onUpdate []func(ours, partner *GameMove)
+}
+
+// NewBughouse creates a bughouse helper for the given game. The helper must be
+// fed with the messages received from the server, for instance by registering
+// its Update method with Client.OnEvent. Once the partner game is known from
+// the game info of our game, the helper observes it automatically
+func NewBughouse(client *Client, game uint32) *Bughouse {
+	return &Bughouse{
+		client: client,
+		game:   game,
+	}
+}
+
+// OnUpdate registers a callback invoked with the latest positions of both
+// boards whenever either of them changes. The partner board is nil until a
+// move of the partner game has been received
+func (b *Bughouse) OnUpdate(fn func(ours, partner *GameMove)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onUpdate = append(b.onUpdate, fn)
+}
+
+// Update applies a message received from the server to the bughouse boards;
+// messages of unrelated games are ignored
+func (b *Bughouse) Update(msg interface{}) {
+	b.mu.Lock()
+
+	var observe, unobserve uint32
+	var update bool
+	switch m := msg.(type) {
+	case *GameStart:
+		if m.GameId == b.game && m.Info != nil {
+			observe = b.link(m.Info.PartnerGame)
+		}
+	case *GameInfo:
+		if m.GameId == b.game {
+			observe = b.link(m.PartnerGame)
+		}
+	case *GameMove:
+		if i := b.board(m.GameId); i != -1 {
+			b.boards[i] = m
+			update = true
+		}
+	case *Holdings:
+		if i := b.board(m.GameId); i != -1 {
+			b.holdings[i] = m
+		}
+	case *GameEnd:
+		if m.GameId == b.game && !b.ended {
+			b.ended = true
+			if b.observing {
+				unobserve = b.partnerGame
+			}
+		}
+	}
+
+	ours, partner := b.boards[0], b.boards[1]
+	callbacks := b.onUpdate
+	b.mu.Unlock()
+
+	if observe != 0 {
+		if err := b.client.Observe(observe); err != nil {
+			log.Printf("observing partner game %d: %v", observe, err)
+		}
+	}
+	if unobserve != 0 {
+		if err := b.client.Unobserve(unobserve); err != nil {
+			log.Printf("unobserving partner game %d: %v", unobserve, err)
+		}
+	}
+	if update {
+		for _, fn := range callbacks {
+			fn(ours, partner)
+		}
+	}
+}
+
+// link records the partner game, returning the game to observe if it was not
+// known before; the caller must hold the lock
+func (b *Bughouse) link(partnerGame uint32) uint32 {
+	if partnerGame == 0 || b.partnerGame == partnerGame || b.ended {
+		return 0
+	}
+	b.partnerGame = partnerGame
+	b.boards[1], b.holdings[1] = nil, nil
+	b.observing = true
+	return partnerGame
+}
+
+// board returns the index of the board of the given game, or -1 if the game
+// is not part of this bughouse game; the caller must hold the lock
+func (b *Bughouse) board(game uint32) int {
+	switch {
+	case game == b.game:
+		return 0
+	case game != 0 && game == b.partnerGame:
+		return 1
+	}
+	return -1
+}
+
+// PartnerGame returns the id of the partner game, or 0 if it is not known yet
+func (b *Bughouse) PartnerGame() uint32 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.partnerGame
+}
+
+// Boards returns the latest positions of our board and of the partner board
+func (b *Bughouse) Boards() (*GameMove, *GameMove) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.boards[0], b.boards[1]
+}
+
+// Holdings returns the pieces in hand of all four players, keyed by handle
+func (b *Bughouse) Holdings() map[string]string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	holdings := make(map[string]string)
+	for i := range b.boards {
+		m, h := b.boards[i], b.holdings[i]
+		if m == nil {
+			continue
+		}
+		white, black := "", ""
+		if h != nil {
+			white, black = h.White, h.Black
+		}
+		holdings[m.WhiteName] = white
+		holdings[m.BlackName] = black
+	}
+	return holdings
+}
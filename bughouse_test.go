@@ -0,0 +1,88 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"testing"
+)
+
+func TestBughouseLinksPartnerGame(t *testing.T) {
+	conn, lines, done := dialWriter(t, "bughouse")
+	defer done()
+	client := &Client{config: &Config{DisableTimeseal: true}, conn: conn}
+
+	b := NewBughouse(client, 103)
+	var updates int
+	var partner *GameMove
+	b.OnUpdate(func(o, p *GameMove) {
+		updates++
+		partner = p
+	})
+
+	var games gameInfos
+	var holdings holdingsTracker
+	feed := func(output string) {
+		msgs := decodeMessages([]byte(output))
+		games.merge(msgs)
+		holdings.merge(msgs)
+		for _, msg := range msgs {
+			b.Update(msg)
+		}
+	}
+
+	// the partner game is observed as soon as our game starts
+	feed("{Game 103 (Alice vs. Bob) Creating rated bughouse match.}\n" +
+		"<g1> 103 p=0 t=bughouse r=1 u=1,1 it=2,2 i=0,0 pt=104 rt=1905,1877  ts=1,1")
+	if got := readLines(t, lines, 1); got[0] != "observe 104" {
+		t.Fatalf("sent %q, want observe 104", got[0])
+	}
+	if b.PartnerGame() != 104 {
+		t.Fatalf("got partner game %d, want 104", b.PartnerGame())
+	}
+
+	// a repeated game info line does not observe the partner game again
+	feed("<g1> 103 p=0 t=bughouse r=1 u=1,1 it=2,2 i=0,0 pt=104 rt=1905,1877  ts=1,1")
+
+	feed("<12> rnbqkbnr pppppppp -------- -------- ----P--- -------- PPPP-PPP RNBQKBNR B 4 1 1 1 1 0 103 Alice Bob -1 2 0 39 39 118 120 1 P/e2-e4 (0:02) e4 0 1 0\n" +
+		"<b1> game 103 white [] black []")
+	if updates != 1 || partner != nil {
+		t.Fatalf("got %d updates with partner board %v, want 1 without partner board", updates, partner)
+	}
+	feed("<12> rnbqkbnr pppp-ppp -------- ----p--- -------- -------- PPPPPPPP RNBQKBNR W 4 1 1 1 1 0 104 Carol Dave 0 2 0 39 39 120 117 1 P/e7-e5 (0:03) e5 0 1 0\n" +
+		"<b1> game 104 white [P] black [] <- WP")
+	if updates != 2 || partner == nil || partner.GameId != 104 {
+		t.Fatalf("got %d updates with partner board %v, want 2 with game 104", updates, partner)
+	}
+
+	// moves of unrelated games are ignored
+	feed("<12> rnbqkbnr pppppppp -------- -------- -------- -------- PPPPPPPP RNBQKBNR W -1 1 1 1 1 0 7 Erin Frank 0 3 0 39 39 180 180 1 none (0:00) none 0 0 0")
+	if updates != 2 {
+		t.Fatalf("got %d updates after a move of another game, want 2", updates)
+	}
+
+	want := map[string]string{"Alice": "", "Bob": "", "Carol": "P", "Dave": ""}
+	got := b.Holdings()
+	if len(got) != len(want) {
+		t.Fatalf("got holdings %v, want %v", got, want)
+	}
+	for handle, h := range want {
+		if got[handle] != h {
+			t.Errorf("got holdings %q for %s, want %q", got[handle], handle, h)
+		}
+	}
+
+	// the partner game is no longer observed at the end of our game
+	feed("{Game 103 (Alice vs. Bob) Bob's partner won} 0-1")
+	if got := readLines(t, lines, 1); got[0] != "unobserve 104" {
+		t.Fatalf("sent %q, want unobserve 104", got[0])
+	}
+	feed("<g1> 103 p=0 t=bughouse r=1 u=1,1 it=2,2 i=0,0 pt=105 rt=1905,1877  ts=1,1")
+	select {
+	case line := <-lines:
+		t.Errorf("sent %q after the end of the game", line)
+	default:
+	}
+}
@@ -0,0 +1,337 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chess
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Move is a move from one square to another, with the type of piece a pawn
+// promotes to if any. Castling is a move of the king by two squares
+type Move struct {
+	From      Square
+	To        Square
+	Promotion PieceType
+}
+
+// direction offsets of the pieces, as (file, rank) pairs
+var (
+	knightOffsets = [][2]int{{1, 2}, {2, 1}, {2, -1}, {1, -2}, {-1, -2}, {-2, -1}, {-2, 1}, {-1, 2}}
+	bishopOffsets = [][2]int{{1, 1}, {1, -1}, {-1, -1}, {-1, 1}}
+	rookOffsets   = [][2]int{{1, 0}, {0, -1}, {-1, 0}, {0, 1}}
+	kingOffsets   = [][2]int{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+)
+
+// the pieces a pawn can promote to
+var promotions = []PieceType{Queen, Rook, Bishop, Knight}
+
+// offset returns the square at the given distance from sq, if it is on the board
+func offset(sq Square, df, dr int) (Square, bool) {
+	f, r := sq.File()+df, sq.Rank()+dr
+	if f < 0 || f > 7 || r < 0 || r > 7 {
+		return NoSquare, false
+	}
+	return NewSquare(f, r), true
+}
+
+// pawnDirection returns the direction in which the pawns of a color advance
+func pawnDirection(c Color) int {
+	if c == White {
+		return 1
+	}
+	return -1
+}
+
+// Attacked reports whether the given square is attacked by a piece of the given color
+func (p *Position) Attacked(sq Square, by Color) bool {
+	// a pawn attacks diagonally in the direction it advances
+	for _, df := range []int{-1, 1} {
+		if s, ok := offset(sq, df, -pawnDirection(by)); ok && p.board[s] == NewPiece(by, Pawn) {
+			return true
+		}
+	}
+	for _, o := range knightOffsets {
+		if s, ok := offset(sq, o[0], o[1]); ok && p.board[s] == NewPiece(by, Knight) {
+			return true
+		}
+	}
+	for _, o := range kingOffsets {
+		if s, ok := offset(sq, o[0], o[1]); ok && p.board[s] == NewPiece(by, King) {
+			return true
+		}
+	}
+	if p.slides(sq, by, bishopOffsets, Bishop) || p.slides(sq, by, rookOffsets, Rook) {
+		return true
+	}
+	return false
+}
+
+// slides reports whether a sliding piece of the given color and type, or a
+// queen, attacks sq along one of the given directions
+func (p *Position) slides(sq Square, by Color, offsets [][2]int, t PieceType) bool {
+	for _, o := range offsets {
+		s := sq
+		for {
+			var ok bool
+			if s, ok = offset(s, o[0], o[1]); !ok {
+				break
+			}
+			pc := p.board[s]
+			if pc == NoPiece {
+				continue
+			}
+			if pc == NewPiece(by, t) || pc == NewPiece(by, Queen) {
+				return true
+			}
+			break
+		}
+	}
+	return false
+}
+
+// InCheck reports whether the side to move is in check
+func (p *Position) InCheck() bool {
+	king := p.King(p.Turn)
+	return king != NoSquare && p.Attacked(king, p.Turn.Other())
+}
+
+// IsCheckmate reports whether the side to move is checkmated
+func (p *Position) IsCheckmate() bool {
+	return p.InCheck() && len(p.LegalMoves()) == 0
+}
+
+// IsStalemate reports whether the side to move is stalemated
+func (p *Position) IsStalemate() bool {
+	return !p.InCheck() && len(p.LegalMoves()) == 0
+}
+
+// pseudoMoves generates the moves of the side to move without regard to
+// whether they leave its king in check
+func (p *Position) pseudoMoves() []Move {
+	var moves []Move
+	us := p.Turn
+	for i, pc := range p.board {
+		from := Square(i)
+		if pc == NoPiece || pc.Color() != us {
+			continue
+		}
+
+		switch pc.Type() {
+		case Pawn:
+			moves = p.pawnMoves(moves, from)
+		case Knight:
+			moves = p.stepMoves(moves, from, knightOffsets)
+		case Bishop:
+			moves = p.slideMoves(moves, from, bishopOffsets)
+		case Rook:
+			moves = p.slideMoves(moves, from, rookOffsets)
+		case Queen:
+			moves = p.slideMoves(moves, from, bishopOffsets)
+			moves = p.slideMoves(moves, from, rookOffsets)
+		case King:
+			moves = p.stepMoves(moves, from, kingOffsets)
+			moves = p.castlingMoves(moves, from)
+		}
+	}
+	return moves
+}
+
+// pawnMoves appends the pushes and captures of the pawn on from
+func (p *Position) pawnMoves(moves []Move, from Square) []Move {
+	us := p.Turn
+	dir := pawnDirection(us)
+	last := 7
+	start := 1
+	if us == Black {
+		last, start = 0, 6
+	}
+
+	add := func(to Square) {
+		if to.Rank() == last {
+			for _, t := range promotions {
+				moves = append(moves, Move{From: from, To: to, Promotion: t})
+			}
+			return
+		}
+		moves = append(moves, Move{From: from, To: to})
+	}
+
+	if to, ok := offset(from, 0, dir); ok && p.board[to] == NoPiece {
+		add(to)
+		if from.Rank() == start {
+			if to2, ok := offset(to, 0, dir); ok && p.board[to2] == NoPiece {
+				add(to2)
+			}
+		}
+	}
+	for _, df := range []int{-1, 1} {
+		to, ok := offset(from, df, dir)
+		if !ok {
+			continue
+		}
+		if pc := p.board[to]; pc != NoPiece && pc.Color() != us {
+			add(to)
+		} else if to == p.EnPassant && pc == NoPiece &&
+			p.board[NewSquare(to.File(), from.Rank())] == NewPiece(us.Other(), Pawn) {
+			add(to)
+		}
+	}
+	return moves
+}
+
+// stepMoves appends the moves of a piece moving a single step in each of the given directions
+func (p *Position) stepMoves(moves []Move, from Square, offsets [][2]int) []Move {
+	for _, o := range offsets {
+		to, ok := offset(from, o[0], o[1])
+		if !ok {
+			continue
+		}
+		if pc := p.board[to]; pc == NoPiece || pc.Color() != p.Turn {
+			moves = append(moves, Move{From: from, To: to})
+		}
+	}
+	return moves
+}
+
+// slideMoves appends the moves of a piece sliding along each of the given directions
+func (p *Position) slideMoves(moves []Move, from Square, offsets [][2]int) []Move {
+	for _, o := range offsets {
+		to := from
+		for {
+			var ok bool
+			if to, ok = offset(to, o[0], o[1]); !ok {
+				break
+			}
+			pc := p.board[to]
+			if pc == NoPiece || pc.Color() != p.Turn {
+				moves = append(moves, Move{From: from, To: to})
+			}
+			if pc != NoPiece {
+				break
+			}
+		}
+	}
+	return moves
+}
+
+// castlingMoves appends the castling moves of the king on from. The squares
+// between the king and the rook must be empty, and the king must not be in
+// check nor pass through an attacked square
+func (p *Position) castlingMoves(moves []Move, from Square) []Move {
+	us, them := p.Turn, p.Turn.Other()
+	king, kingSide, queenSide := E1, WhiteKingSide, WhiteQueenSide
+	if us == Black {
+		king, kingSide, queenSide = E8, BlackKingSide, BlackQueenSide
+	}
+	if from != king || p.Attacked(king, them) {
+		return moves
+	}
+
+	rook := NewPiece(us, Rook)
+	if p.Castling&kingSide != 0 && p.board[king+3] == rook &&
+		p.board[king+1] == NoPiece && p.board[king+2] == NoPiece &&
+		!p.Attacked(king+1, them) && !p.Attacked(king+2, them) {
+		moves = append(moves, Move{From: king, To: king + 2})
+	}
+	if p.Castling&queenSide != 0 && p.board[king-4] == rook &&
+		p.board[king-1] == NoPiece && p.board[king-2] == NoPiece && p.board[king-3] == NoPiece &&
+		!p.Attacked(king-1, them) && !p.Attacked(king-2, them) {
+		moves = append(moves, Move{From: king, To: king - 2})
+	}
+	return moves
+}
+
+// LegalMoves returns the legal moves of the side to move
+func (p *Position) LegalMoves() []Move {
+	var moves []Move
+	for _, m := range p.pseudoMoves() {
+		next := p.apply(m)
+		if king := next.King(p.Turn); king == NoSquare || !next.Attacked(king, next.Turn) {
+			moves = append(moves, m)
+		}
+	}
+	return moves
+}
+
+// IsLegal reports whether the move is legal in the position
+func (p *Position) IsLegal(m Move) bool {
+	for _, l := range p.LegalMoves() {
+		if l == m {
+			return true
+		}
+	}
+	return false
+}
+
+// Play returns the position after the given move, or an error if the move is
+// not legal. The position itself is left unchanged
+func (p *Position) Play(m Move) (*Position, error) {
+	if !p.IsLegal(m) {
+		return nil, errors.Errorf("illegal move %s in position %s", m, p.FEN())
+	}
+	return p.apply(m), nil
+}
+
+// apply returns the position after the given move, which is assumed to be
+// pseudo-legal
+func (p *Position) apply(m Move) *Position {
+	next := *p
+	pc := next.board[m.From]
+	captured := next.board[m.To]
+	next.board[m.From] = NoPiece
+	next.board[m.To] = pc
+	next.EnPassant = NoSquare
+
+	switch pc.Type() {
+	case Pawn:
+		if m.To == p.EnPassant {
+			// the captured pawn is behind the square moved to
+			next.board[NewSquare(m.To.File(), m.From.Rank())] = NoPiece
+		}
+		if m.Promotion != NoPieceType {
+			next.board[m.To] = NewPiece(p.Turn, m.Promotion)
+		}
+		if d := int(m.To) - int(m.From); d == 16 || d == -16 {
+			next.EnPassant = (m.From + m.To) / 2
+		}
+	case King:
+		switch int(m.To) - int(m.From) {
+		case 2:
+			next.board[m.From+1], next.board[m.From+3] = next.board[m.From+3], NoPiece
+		case -2:
+			next.board[m.From-1], next.board[m.From-4] = next.board[m.From-4], NoPiece
+		}
+	}
+
+	// moving the king, or moving or capturing a rook, gives up castling
+	for _, sq := range []Square{m.From, m.To} {
+		switch sq {
+		case E1:
+			next.Castling &^= WhiteKingSide | WhiteQueenSide
+		case E8:
+			next.Castling &^= BlackKingSide | BlackQueenSide
+		case H1:
+			next.Castling &^= WhiteKingSide
+		case A1:
+			next.Castling &^= WhiteQueenSide
+		case H8:
+			next.Castling &^= BlackKingSide
+		case A8:
+			next.Castling &^= BlackQueenSide
+		}
+	}
+
+	if pc.Type() == Pawn || captured != NoPiece {
+		next.HalfmoveClock = 0
+	} else {
+		next.HalfmoveClock++
+	}
+	if p.Turn == Black {
+		next.FullmoveNumber++
+	}
+	next.Turn = p.Turn.Other()
+	return &next
+}
@@ -0,0 +1,316 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chess
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// the letters of the piece types in SAN, indexed by piece type
+const pieceTypeLetters = " PNBRQK"
+
+// String returns the SAN letter of the piece type
+func (t PieceType) String() string {
+	if t <= NoPieceType || int(t) >= len(pieceTypeLetters) {
+		return ""
+	}
+	return pieceTypeLetters[t : t+1]
+}
+
+// parsePieceType parses the letter of a piece type, in either case
+func parsePieceType(c byte) PieceType {
+	i := strings.IndexByte(pieceTypeLetters, c&^0x20)
+	if i < 1 {
+		return NoPieceType
+	}
+	return PieceType(i)
+}
+
+// String returns the move in UCI notation
+func (m Move) String() string {
+	s := m.From.String() + m.To.String()
+	if m.Promotion != NoPieceType {
+		s += strings.ToLower(m.Promotion.String())
+	}
+	return s
+}
+
+// isCastling reports whether the move is a castling move in the position
+func (p *Position) isCastling(m Move) bool {
+	d := int(m.To) - int(m.From)
+	return p.board[m.From].Type() == King && (d == 2 || d == -2)
+}
+
+// UCI returns the move in UCI notation, such as e2e4 or e7e8q
+func (p *Position) UCI(m Move) string {
+	return m.String()
+}
+
+// ParseUCI parses a move in UCI notation, returning an error if it is not
+// legal in the position
+func (p *Position) ParseUCI(s string) (Move, error) {
+	if len(s) != 4 && len(s) != 5 {
+		return Move{}, errors.Errorf("invalid UCI move %q", s)
+	}
+	from, err := ParseSquare(s[0:2])
+	if err != nil {
+		return Move{}, errors.Wrapf(err, "invalid UCI move %q", s)
+	}
+	to, err := ParseSquare(s[2:4])
+	if err != nil {
+		return Move{}, errors.Wrapf(err, "invalid UCI move %q", s)
+	}
+	m := Move{From: from, To: to}
+	if len(s) == 5 {
+		if m.Promotion = parsePieceType(s[4]); m.Promotion == NoPieceType {
+			return Move{}, errors.Errorf("invalid UCI move %q: unknown promotion", s)
+		}
+	}
+	if !p.IsLegal(m) {
+		return Move{}, errors.Errorf("illegal move %s in position %s", s, p.FEN())
+	}
+	return m, nil
+}
+
+// LAN returns the move in long algebraic notation, such as Ng1-f3, e4xd5 or
+// e7-e8=Q, followed by + or # if it gives check or mate
+func (p *Position) LAN(m Move) string {
+	var s string
+	if p.isCastling(m) {
+		s = castlingSAN(m)
+	} else {
+		pc := p.board[m.From]
+		if pc.Type() != Pawn {
+			s = pc.Type().String()
+		}
+		sep := "-"
+		if p.isCapture(m) {
+			sep = "x"
+		}
+		s += m.From.String() + sep + m.To.String()
+		if m.Promotion != NoPieceType {
+			s += "=" + m.Promotion.String()
+		}
+	}
+	return s + p.checkSuffix(m)
+}
+
+// ParseLAN parses a move in long algebraic notation, returning an error if
+// it is not legal in the position
+func (p *Position) ParseLAN(s string) (Move, error) {
+	t := trimAnnotations(s)
+	if m, ok := p.parseCastling(t); ok {
+		return m, nil
+	}
+
+	if len(t) > 0 && parsePieceType(t[0]) != NoPieceType && t[0] >= 'A' && t[0] <= 'Z' {
+		t = t[1:]
+	}
+	if len(t) < 5 || (t[2] != '-' && t[2] != 'x') {
+		return Move{}, errors.Errorf("invalid LAN move %q", s)
+	}
+	uci := t[0:2] + t[3:5]
+	if promo := strings.TrimPrefix(t[5:], "="); len(promo) == 1 {
+		uci += promo
+	} else if len(promo) > 1 {
+		return Move{}, errors.Errorf("invalid LAN move %q", s)
+	}
+	m, err := p.ParseUCI(uci)
+	if err != nil {
+		return Move{}, errors.Wrapf(err, "invalid LAN move %q", s)
+	}
+	return m, nil
+}
+
+// SAN returns the move in standard algebraic notation, such as Nf3, exd5 or
+// e8=Q+
+func (p *Position) SAN(m Move) string {
+	return p.san(m, p.LegalMoves()) + p.checkSuffix(m)
+}
+
+// san returns the move in standard algebraic notation without check
+// indicators, disambiguating it among the given legal moves
+func (p *Position) san(m Move, legal []Move) string {
+	if p.isCastling(m) {
+		return castlingSAN(m)
+	}
+
+	pc := p.board[m.From]
+	var s string
+	if pc.Type() == Pawn {
+		if p.isCapture(m) {
+			s = m.From.String()[:1] + "x"
+		}
+		s += m.To.String()
+		if m.Promotion != NoPieceType {
+			s += "=" + m.Promotion.String()
+		}
+		return s
+	}
+
+	// disambiguate between pieces of the same type moving to the same square
+	var ambiguous, sameFile, sameRank bool
+	for _, l := range legal {
+		if l.To != m.To || l.From == m.From || p.board[l.From] != pc {
+			continue
+		}
+		ambiguous = true
+		sameFile = sameFile || l.From.File() == m.From.File()
+		sameRank = sameRank || l.From.Rank() == m.From.Rank()
+	}
+
+	s = pc.Type().String()
+	switch {
+	case !ambiguous:
+	case !sameFile:
+		s += m.From.String()[:1]
+	case !sameRank:
+		s += m.From.String()[1:]
+	default:
+		s += m.From.String()
+	}
+	if p.isCapture(m) {
+		s += "x"
+	}
+	return s + m.To.String()
+}
+
+// ParseSAN parses a move in standard algebraic notation, returning an error
+// if it is not legal in the position. Check indicators and annotations are
+// optional, as is the = sign of promotions, whose piece may be in either
+// case. Moves that give more of the square they leave than needed, such as
+// Ng1e2, are accepted as long as they are not ambiguous
+func (p *Position) ParseSAN(s string) (Move, error) {
+	t := trimAnnotations(s)
+	if m, ok := p.parseCastling(t); ok {
+		return m, nil
+	}
+
+	t = normalizeSAN(t)
+	pt := Pawn
+	if len(t) > 0 && t[0] >= 'A' && t[0] <= 'Z' {
+		if pt = parsePieceType(t[0]); pt == NoPieceType || pt == Pawn {
+			return Move{}, errors.Errorf("invalid SAN move %q", s)
+		}
+		t = t[1:]
+	}
+
+	promo := NoPieceType
+	if n := len(t); n > 2 && t[n-2] >= '1' && t[n-2] <= '8' {
+		if promo = parsePieceType(t[n-1]); promo == NoPieceType || promo == Pawn || promo == King {
+			return Move{}, errors.Errorf("invalid SAN move %q", s)
+		}
+		t = t[:n-1]
+	}
+	if len(t) < 2 {
+		return Move{}, errors.Errorf("invalid SAN move %q", s)
+	}
+	to, err := ParseSquare(t[len(t)-2:])
+	if err != nil {
+		return Move{}, errors.Errorf("invalid SAN move %q", s)
+	}
+	from := t[:len(t)-2]
+	capture := strings.HasSuffix(from, "x")
+	from = strings.TrimSuffix(from, "x")
+
+	// the file and rank of the square left, if given
+	file, rank := -1, -1
+	for i := 0; i < len(from); i++ {
+		switch c := from[i]; {
+		case c >= 'a' && c <= 'h' && file == -1 && rank == -1:
+			file = int(c - 'a')
+		case c >= '1' && c <= '8' && rank == -1:
+			rank = int(c - '1')
+		default:
+			return Move{}, errors.Errorf("invalid SAN move %q", s)
+		}
+	}
+	if pt == Pawn && capture && file == -1 {
+		return Move{}, errors.Errorf("invalid SAN move %q", s)
+	}
+
+	var found []Move
+	for _, m := range p.LegalMoves() {
+		if m.To != to || m.Promotion != promo || p.board[m.From].Type() != pt || p.isCastling(m) {
+			continue
+		}
+		if (file != -1 && m.From.File() != file) || (rank != -1 && m.From.Rank() != rank) {
+			continue
+		}
+		if capture && !p.isCapture(m) {
+			continue
+		}
+		found = append(found, m)
+	}
+	switch len(found) {
+	case 0:
+		return Move{}, errors.Errorf("illegal or invalid move %s in position %s", s, p.FEN())
+	case 1:
+		return found[0], nil
+	default:
+		return Move{}, errors.Errorf("ambiguous move %s in position %s", s, p.FEN())
+	}
+}
+
+// isCapture reports whether the move captures a piece, including en passant
+func (p *Position) isCapture(m Move) bool {
+	if p.board[m.To] != NoPiece {
+		return true
+	}
+	return p.board[m.From].Type() == Pawn && m.From.File() != m.To.File()
+}
+
+// checkSuffix returns # if the move mates, + if it checks, and nothing otherwise
+func (p *Position) checkSuffix(m Move) string {
+	next := p.apply(m)
+	if !next.InCheck() {
+		return ""
+	}
+	if len(next.LegalMoves()) == 0 {
+		return "#"
+	}
+	return "+"
+}
+
+// castlingSAN returns the notation of a castling move
+func castlingSAN(m Move) string {
+	if m.To > m.From {
+		return "O-O"
+	}
+	return "O-O-O"
+}
+
+// parseCastling parses O-O and O-O-O, also written with zeros, if castling
+// that way is legal in the position
+func (p *Position) parseCastling(s string) (Move, bool) {
+	s = strings.Replace(s, "0", "O", -1)
+	if s != "O-O" && s != "O-O-O" {
+		return Move{}, false
+	}
+	king := E1
+	if p.Turn == Black {
+		king = E8
+	}
+	m := Move{From: king, To: king + 2}
+	if s == "O-O-O" {
+		m.To = king - 2
+	}
+	return m, p.board[king].Type() == King && p.IsLegal(m)
+}
+
+// trimAnnotations removes check indicators and move annotations
+func trimAnnotations(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "e.p.")
+	return strings.TrimRight(s, "+#!?")
+}
+
+// normalizeSAN removes the optional parts of a move in SAN
+func normalizeSAN(s string) string {
+	return strings.Replace(s, "=", "", -1)
+}
@@ -0,0 +1,74 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chess
+
+import "testing"
+
+// every legal move of the perft positions and of those reached from them
+// parses back from its SAN
+func TestSANRoundTrip(t *testing.T) {
+	for _, tt := range perftTests {
+		p, err := ParseFEN(tt.fen)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		for _, m := range p.LegalMoves() {
+			next := p.apply(m)
+			for _, pos := range []*Position{p, next} {
+				for _, l := range pos.LegalMoves() {
+					san := pos.SAN(l)
+					got, err := pos.ParseSAN(san)
+					if err != nil {
+						t.Fatalf("%s: %s in %s: %v", tt.name, san, pos.FEN(), err)
+					}
+					if got != l {
+						t.Fatalf("%s: %s in %s parsed as %v, want %v", tt.name, san, pos.FEN(), got, l)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestParseSAN(t *testing.T) {
+	tests := []struct {
+		fen  string
+		san  string
+		want string
+	}{
+		{StartFEN, "Nf3", "g1f3"},
+		{StartFEN, "Ngf3", "g1f3"},
+		{StartFEN, "Ng1f3", "g1f3"},
+		{StartFEN, "e4!?", "e2e4"},
+		{"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1", "Nge2", ""},
+		{"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 0 1", "Nge2", "g1e2"},
+		{"4k3/4P3/8/8/8/8/8/4K2R w K - 0 1", "O-O", "e1g1"},
+		{"8/4P1k1/8/8/8/8/8/4K3 w - - 0 1", "e8=Q", "e7e8q"},
+		{"8/4P1k1/8/8/8/8/8/4K3 w - - 0 1", "e8=q", "e7e8q"},
+		{"8/4P1k1/8/8/8/8/8/4K3 w - - 0 1", "e8n+", "e7e8n"},
+		{"8/4P1k1/8/8/8/8/8/4K3 w - - 0 1", "e8", ""},
+		{"4k3/8/8/8/8/2N3N1/8/4K3 w - - 0 1", "Ne4", ""},
+		{"4k3/8/8/8/8/2N3N1/8/4K3 w - - 0 1", "Nce4", "c3e4"},
+		{"4k3/8/8/3p4/4P3/8/8/4K3 w - - 0 1", "exd5", "e4d5"},
+		{"4k3/8/8/3p4/4P3/8/8/4K3 w - - 0 1", "xd5", ""},
+		{"4k3/8/8/3p4/4P3/8/8/4K3 w - - 0 1", "Kxe2", ""},
+	}
+	for _, tt := range tests {
+		p, err := ParseFEN(tt.fen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := p.ParseSAN(tt.san)
+		switch {
+		case tt.want == "" && err == nil:
+			t.Errorf("%s in %s: got %v, want an error", tt.san, tt.fen, m)
+		case tt.want != "" && err != nil:
+			t.Errorf("%s in %s: %v", tt.san, tt.fen, err)
+		case tt.want != "" && m.String() != tt.want:
+			t.Errorf("%s in %s: got %v, want %s", tt.san, tt.fen, m, tt.want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package chess
+
+import "testing"
+
+// perft counts the leaf nodes of the tree of legal moves of the given depth
+func perft(p *Position, depth int) int {
+	moves := p.LegalMoves()
+	if depth == 1 {
+		return len(moves)
+	}
+	n := 0
+	for _, m := range moves {
+		n += perft(p.apply(m), depth-1)
+	}
+	return n
+}
+
+// the positions and counts of https://www.chessprogramming.org/Perft_Results
+var perftTests = []struct {
+	name  string
+	fen   string
+	depth int
+	nodes int
+}{
+	{"initial", StartFEN, 3, 8902},
+	{"kiwipete", "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1", 3, 97862},
+	{"position 3", "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", 4, 43238},
+	{"position 4", "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", 3, 9467},
+	{"position 5", "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", 3, 62379},
+}
+
+func TestPerft(t *testing.T) {
+	for _, tt := range perftTests {
+		p, err := ParseFEN(tt.fen)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if n := perft(p, tt.depth); n != tt.nodes {
+			t.Errorf("%s: got %d nodes at depth %d, want %d", tt.name, n, tt.depth, tt.nodes)
+		}
+	}
+}
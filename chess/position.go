@@ -0,0 +1,324 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package chess implements the rules of chess: a board model, legal move
+// generation, move notations and FEN import and export
+package chess
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// StartFEN is the FEN of the initial position of a game of chess
+const StartFEN = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+// Color is the color of a player or a piece
+type Color int8
+
+// colors of the players
+const (
+	White Color = iota
+	Black
+)
+
+// Other returns the opposite color
+func (c Color) Other() Color {
+	return c ^ 1
+}
+
+func (c Color) String() string {
+	if c == White {
+		return "w"
+	}
+	return "b"
+}
+
+// PieceType is the type of a piece, regardless of its color
+type PieceType int8
+
+// types of pieces
+const (
+	NoPieceType PieceType = iota
+	Pawn
+	Knight
+	Bishop
+	Rook
+	Queen
+	King
+)
+
+// Piece is a piece of a given color
+type Piece int8
+
+// NoPiece is the content of an empty square
+const NoPiece Piece = 0
+
+// NewPiece returns the piece of the given color and type
+func NewPiece(c Color, t PieceType) Piece {
+	if t == NoPieceType {
+		return NoPiece
+	}
+	return Piece(int8(c)*6 + int8(t))
+}
+
+// Type returns the type of the piece
+func (p Piece) Type() PieceType {
+	if p == NoPiece {
+		return NoPieceType
+	}
+	return PieceType((p-1)%6 + 1)
+}
+
+// Color returns the color of the piece
+func (p Piece) Color() Color {
+	return Color((p - 1) / 6)
+}
+
+// the letters of the pieces in FEN, indexed by piece
+const pieceLetters = " PNBRQKpnbrqk"
+
+// String returns the FEN letter of the piece
+func (p Piece) String() string {
+	if p <= NoPiece || int(p) >= len(pieceLetters) {
+		return ""
+	}
+	return pieceLetters[p : p+1]
+}
+
+// Square is a square of the board, from A1 (0) to H8 (63)
+type Square int8
+
+// NoSquare denotes the absence of a square, e.g. when no en passant capture is possible
+const NoSquare Square = -1
+
+// squares used in castling
+const (
+	A1 Square = 0
+	C1 Square = 2
+	D1 Square = 3
+	E1 Square = 4
+	F1 Square = 5
+	G1 Square = 6
+	H1 Square = 7
+	A8 Square = 56
+	C8 Square = 58
+	D8 Square = 59
+	E8 Square = 60
+	F8 Square = 61
+	G8 Square = 62
+	H8 Square = 63
+)
+
+// NewSquare returns the square on the given file and rank, both from 0 to 7
+func NewSquare(file, rank int) Square {
+	return Square(rank*8 + file)
+}
+
+// ParseSquare parses a square in algebraic notation, such as e4
+func ParseSquare(s string) (Square, error) {
+	if len(s) != 2 || s[0] < 'a' || s[0] > 'h' || s[1] < '1' || s[1] > '8' {
+		return NoSquare, errors.Errorf("invalid square %q", s)
+	}
+	return NewSquare(int(s[0]-'a'), int(s[1]-'1')), nil
+}
+
+// File returns the file of the square, from 0 (a) to 7 (h)
+func (sq Square) File() int {
+	return int(sq) % 8
+}
+
+// Rank returns the rank of the square, from 0 (1) to 7 (8)
+func (sq Square) Rank() int {
+	return int(sq) / 8
+}
+
+// String returns the square in algebraic notation
+func (sq Square) String() string {
+	if sq < 0 || sq > 63 {
+		return "-"
+	}
+	return string([]byte{byte('a' + sq.File()), byte('1' + sq.Rank())})
+}
+
+// CastlingRights are the castling moves still available to the players
+type CastlingRights uint8
+
+// castling rights
+const (
+	WhiteKingSide CastlingRights = 1 << iota
+	WhiteQueenSide
+	BlackKingSide
+	BlackQueenSide
+)
+
+// Position is a position of a game of chess
+type Position struct {
+	board          [64]Piece
+	Turn           Color
+	Castling       CastlingRights
+	EnPassant      Square
+	HalfmoveClock  int
+	FullmoveNumber int
+}
+
+// NewPosition returns the initial position of a game of chess
+func NewPosition() *Position {
+	p, _ := ParseFEN(StartFEN)
+	return p
+}
+
+// ParseFEN parses a position in Forsyth-Edwards Notation. Holdings in
+// brackets after the piece placement, as found in crazyhouse, are ignored
+func ParseFEN(fen string) (*Position, error) {
+	fields := strings.Fields(fen)
+	if len(fields) < 4 {
+		return nil, errors.Errorf("invalid FEN %q: too few fields", fen)
+	}
+
+	placement := fields[0]
+	if i := strings.IndexByte(placement, '['); i != -1 {
+		placement = placement[:i]
+	}
+	ranks := strings.Split(placement, "/")
+	if len(ranks) != 8 {
+		return nil, errors.Errorf("invalid FEN %q: expected 8 ranks", fen)
+	}
+
+	p := &Position{EnPassant: NoSquare, FullmoveNumber: 1}
+	for i, r := range ranks {
+		rank, file := 7-i, 0
+		for _, c := range r {
+			if file > 7 {
+				return nil, errors.Errorf("invalid FEN %q: rank %d is too long", fen, rank+1)
+			}
+			if c >= '1' && c <= '8' {
+				file += int(c - '0')
+				continue
+			}
+			j := strings.IndexRune(pieceLetters, c)
+			if j < 1 {
+				return nil, errors.Errorf("invalid FEN %q: unknown piece %q", fen, c)
+			}
+			p.board[NewSquare(file, rank)] = Piece(j)
+			file++
+		}
+		if file != 8 {
+			return nil, errors.Errorf("invalid FEN %q: rank %d has %d files", fen, rank+1, file)
+		}
+	}
+
+	switch fields[1] {
+	case "w":
+		p.Turn = White
+	case "b":
+		p.Turn = Black
+	default:
+		return nil, errors.Errorf("invalid FEN %q: unknown side to move %q", fen, fields[1])
+	}
+
+	if fields[2] != "-" {
+		for _, c := range fields[2] {
+			switch c {
+			case 'K':
+				p.Castling |= WhiteKingSide
+			case 'Q':
+				p.Castling |= WhiteQueenSide
+			case 'k':
+				p.Castling |= BlackKingSide
+			case 'q':
+				p.Castling |= BlackQueenSide
+			default:
+				return nil, errors.Errorf("invalid FEN %q: unknown castling right %q", fen, c)
+			}
+		}
+	}
+
+	if fields[3] != "-" {
+		sq, err := ParseSquare(fields[3])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid FEN %q", fen)
+		}
+		p.EnPassant = sq
+	}
+
+	if len(fields) > 4 {
+		n, err := strconv.Atoi(fields[4])
+		if err != nil || n < 0 {
+			return nil, errors.Errorf("invalid FEN %q: bad halfmove clock", fen)
+		}
+		p.HalfmoveClock = n
+	}
+	if len(fields) > 5 {
+		n, err := strconv.Atoi(fields[5])
+		if err != nil || n < 1 {
+			return nil, errors.Errorf("invalid FEN %q: bad move number", fen)
+		}
+		p.FullmoveNumber = n
+	}
+	return p, nil
+}
+
+// FEN returns the position in Forsyth-Edwards Notation
+func (p *Position) FEN() string {
+	var b strings.Builder
+	for rank := 7; rank >= 0; rank-- {
+		empty := 0
+		for file := 0; file < 8; file++ {
+			pc := p.board[NewSquare(file, rank)]
+			if pc == NoPiece {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				b.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			b.WriteString(pc.String())
+		}
+		if empty > 0 {
+			b.WriteString(strconv.Itoa(empty))
+		}
+		if rank > 0 {
+			b.WriteByte('/')
+		}
+	}
+
+	b.WriteString(" " + p.Turn.String() + " ")
+	castling := ""
+	for i, c := range "KQkq" {
+		if p.Castling&(1<<uint(i)) != 0 {
+			castling += string(c)
+		}
+	}
+	if castling == "" {
+		castling = "-"
+	}
+	b.WriteString(castling + " " + p.EnPassant.String())
+	b.WriteString(" " + strconv.Itoa(p.HalfmoveClock) + " " + strconv.Itoa(p.FullmoveNumber))
+	return b.String()
+}
+
+// Piece returns the piece on the given square
+func (p *Position) Piece(sq Square) Piece {
+	if sq < 0 || sq > 63 {
+		return NoPiece
+	}
+	return p.board[sq]
+}
+
+// King returns the square of the king of the given color, or NoSquare if
+// there is none
+func (p *Position) King(c Color) Square {
+	king := NewPiece(c, King)
+	for sq, pc := range p.board {
+		if pc == king {
+			return Square(sq)
+		}
+	}
+	return NoSquare
+}
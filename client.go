@@ -8,9 +8,11 @@
 package icsgo
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"regexp"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -26,25 +28,81 @@ type Config struct {
 	ConnTimeout      int
 	ConnRetries      int
 	Debug            bool
+	// Reconnect enables the reconnect supervisor in Run, which redials the
+	// server up to ConnRetries times when the connection drops
+	Reconnect bool
+	// ReconnectDelay is the initial delay (in seconds) between reconnect
+	// attempts, doubled after every failed attempt
+	ReconnectDelay int
+	// ReconnectMaxDelay caps the delay (in seconds) between reconnect attempts
+	ReconnectMaxDelay int
+	// BlockMode enables FICS block mode after login, which is required to
+	// correlate commands with their replies using Client.Do
+	BlockMode bool
+	// Ivariables are the interface variables, such as seekinfo, pendinfo,
+	// gameinfo, pin or ms, enabled after login. The server must confirm all
+	// of them, after which they are locked with iset lock 1. Ivariables whose
+	// output the client does not understand, such as xml, are rejected
+	Ivariables []string
+	// TLS configures connections to tls:// and wss:// addresses. If it is
+	// set, addresses without a scheme are dialed over TLS too
+	TLS *TLSConfig
+	// Proxy is the URL of a socks5:// or http:// proxy that connections to
+	// the server go through, with optional user:password credentials
+	Proxy string
+	// Dialer, if set, dials the TCP connections to the server, or to the
+	// proxy, instead of net.Dialer
+	Dialer DialFunc
+	// SendRate limits the number of commands sent to the server per second,
+	// on average, as FICS penalises clients that flood it. A negative rate
+	// removes the limit
+	SendRate float64
+	// SendBurst is the number of commands that can be sent at once, within
+	// the SendRate limit
+	SendBurst int
+	// DisablePriorities sends the commands of concurrent callers in the
+	// order they were sent rather than moves first and chat last
+	DisablePriorities bool
 }
 
 // DefaultConfig represents the default configuration of icsgo client
 var DefaultConfig = &Config{
-	UserPrompt:       "login:",
-	PasswordPrompt:   "password:",
-	ICSPrompt:        "fics%",
-	DisableKeepAlive: false,
-	DisableTimeseal:  false,
-	ConnTimeout:      2,
-	ConnRetries:      5,
-	Debug:            false,
+	UserPrompt:        "login:",
+	PasswordPrompt:    "password:",
+	ICSPrompt:         "fics%",
+	DisableKeepAlive:  false,
+	DisableTimeseal:   false,
+	ConnTimeout:       2,
+	ConnRetries:       5,
+	Debug:             false,
+	ReconnectDelay:    1,
+	ReconnectMaxDelay: 60,
+	SendRate:          5,
+	SendBurst:         10,
 }
 
 // Client represents a new ICS client
 type Client struct {
-	config   *Config
-	conn     *Conn
-	username string
+	config     *Config
+	addr       string
+	loginName  string
+	password   string
+	connMu     sync.RWMutex
+	conn       *Conn
+	username   string
+	features   map[string]bool
+	icsPrompt  string
+	pending    []byte
+	closed     bool
+	session    session
+	blocks     blocks
+	games      gameInfos
+	compressed compressedMoves
+	holdings   holdingsTracker
+	handlers   handlers
+	bus        bus
+	runMu      sync.Mutex
+	running    bool
 }
 
 func getConfig(cfg *Config) *Config {
@@ -73,84 +131,194 @@ func getConfig(cfg *Config) *Config {
 		cfg.ConnRetries = DefaultConfig.ConnRetries
 	}
 
+	if cfg.ReconnectDelay == 0 {
+		cfg.ReconnectDelay = DefaultConfig.ReconnectDelay
+	}
+
+	if cfg.ReconnectMaxDelay == 0 {
+		cfg.ReconnectMaxDelay = DefaultConfig.ReconnectMaxDelay
+	}
+
+	if cfg.SendRate == 0 {
+		cfg.SendRate = DefaultConfig.SendRate
+	}
+
+	if cfg.SendBurst == 0 {
+		cfg.SendBurst = DefaultConfig.SendBurst
+	}
+
 	return cfg
 }
 
 // NewClient creates a new ICS client
 func NewClient(cfg *Config, addr, username, password string) (*Client, error) {
+	return NewClientContext(context.Background(), cfg, addr, username, password)
+}
+
+// NewClientContext creates a new ICS client. The context bounds connecting to
+// and authenticating with the server; it is not retained by the client
+func NewClientContext(ctx context.Context, cfg *Config, addr, username, password string) (*Client, error) {
 	cfg = getConfig(cfg)
-	retries := cfg.ConnRetries
-	timeout := time.Duration(cfg.ConnTimeout) * time.Second
-	conn, err := Dial(addr, retries, timeout, !cfg.DisableTimeseal, cfg.Debug)
+	conn, user, n, err := connect(ctx, cfg, cfg.ConnRetries, addr, username, password)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create new connection")
+		return nil, err
 	}
 
-	username, err = login(conn, username, password, cfg)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to authenticate to server")
+	client := &Client{
+		config:    cfg,
+		addr:      addr,
+		loginName: username,
+		password:  password,
+		conn:      conn,
+		username:  user,
+		features:  n.features,
+		icsPrompt: n.prompt,
+		pending:   n.pending,
 	}
 
 	if !cfg.DisableKeepAlive {
-		go keepAlive(conn)
+		go client.keepAlive(conn)
+	}
+
+	return client, nil
+}
+
+// connect dials the server, logs in with the given credentials and
+// negotiates the ivariables of the connection
+func connect(ctx context.Context, cfg *Config, retries int, addr, username, password string) (*Conn, string, *negotiated, error) {
+	conn, err := dialConfig(ctx, cfg, addr, retries)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, "", nil, ctx.Err()
+		}
+		return nil, "", nil, errors.Wrap(err, "failed to create new connection")
+	}
+
+	username, err = login(ctx, conn, username, password, cfg)
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, "", nil, ctx.Err()
+		}
+		return nil, "", nil, errors.Wrap(err, "failed to authenticate to server")
+	}
+
+	n, err := negotiate(ctx, conn, cfg)
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, "", nil, ctx.Err()
+		}
+		return nil, "", nil, errors.Wrap(err, "failed to negotiate ivariables")
 	}
 
-	return &Client{
-		config:   cfg,
-		conn:     conn,
-		username: username,
-	}, nil
+	return conn, username, n, nil
 }
 
 // Send sends a message to the ICS server
 func (client *Client) Send(msg []byte) error {
-	if client.config.DisableTimeseal {
+	client.session.record(string(msg))
+	_, err := client.write(client.getConn(), msg, nil)
+	return err
+}
+
+// writeCommand writes a command on the given connection with the given
+// priority, terminating it with a newline when timeseal, which frames
+// commands itself, is disabled
+func writeCommand(conn *Conn, cfg *Config, msg []byte, p Priority) error {
+	if cfg.DisableTimeseal {
 		msg = append(msg, "\n"...)
 	}
-	return client.conn.Write(msg)
+	return conn.WritePriority(msg, p)
 }
 
-// Send sends a timeseal-encoded message to the ICS server
+// RawSend sends a message to the ICS server as it is, without timeseal
+// encoding, ahead of the queued messages and regardless of the rate limit
 func (client *Client) RawSend(msg []byte) error {
-	return client.conn.RawWrite(msg)
+	return client.getConn().RawWrite(msg)
 }
 
-// Recv receives messages from the ICS server
+// Recv receives messages from the ICS server, decoding them as soon as they
+// are complete rather than when the next prompt arrives
 func (client *Client) Recv() ([]interface{}, error) {
-	out, err := client.conn.ReadUntil(client.config.ICSPrompt)
+	if pending := client.takePending(); pending != nil {
+		return client.decode(pending), nil
+	}
+
+	out, err := client.getConn().ReadMessage(client.prompt())
+	if err != nil {
+		return nil, err
+	}
+
+	return client.decode(out), nil
+}
+
+// RecvContext receives messages from the ICS server, blocking until the next
+// message is complete or the context is done
+func (client *Client) RecvContext(ctx context.Context) ([]interface{}, error) {
+	if pending := client.takePending(); pending != nil {
+		return client.decode(pending), nil
+	}
+
+	out, err := client.getConn().ReadMessageContext(ctx, client.prompt())
 	if err != nil {
 		return nil, err
 	}
 
-	return decodeMessages(out), nil
+	return client.decode(out), nil
 }
 
 // Username returns the username of user associated with the client
 func (client *Client) Username() string {
+	client.connMu.RLock()
+	defer client.connMu.RUnlock()
 	return client.username
 }
 
-// Destroy destroys a client instance
+// Destroy destroys a client instance, closing the channels returned by Subscribe
 func (client *Client) Destroy() {
+	client.connMu.Lock()
+	client.closed = true
+	client.connMu.Unlock()
 	client.Send([]byte("exit"))
-	client.conn.Close()
+	client.getConn().Close()
+	client.bus.closeAll(true)
+}
+
+// isClosed reports whether the client was destroyed
+func (client *Client) isClosed() bool {
+	client.connMu.RLock()
+	defer client.connMu.RUnlock()
+	return client.closed
+}
+
+// getConn returns the current connection of the client, which changes when
+// the client reconnects
+func (client *Client) getConn() *Conn {
+	client.connMu.RLock()
+	defer client.connMu.RUnlock()
+	return client.conn
 }
 
-func keepAlive(conn *Conn) {
+func (client *Client) keepAlive(conn *Conn) {
 	for {
 		time.Sleep(58 * time.Minute)
-		conn.Write([]byte("ping"))
+		if client.getConn() != conn {
+			return
+		}
+		if _, err := client.write(conn, []byte("ping"), nil); err != nil {
+			return
+		}
 	}
 }
 
-//
-func login(conn *Conn, username, password string, cfg *Config) (string, error) {
+func login(ctx context.Context, conn *Conn, username, password string, cfg *Config) (string, error) {
 	if conn == nil {
 		return "", fmt.Errorf("client not connected")
 	}
 
 	// wait for the login prompt
-	_, err := conn.ReadUntilTimeout(cfg.UserPrompt, 10*time.Second)
+	_, err := readUntilTimeout(ctx, conn, cfg.UserPrompt, 10*time.Second)
 	if err != nil {
 		return "", fmt.Errorf("creating new login session for %s: %v", username, err)
 	}
@@ -171,14 +339,14 @@ func login(conn *Conn, username, password string, cfg *Config) (string, error) {
 	}
 
 	// wait for the password prompt
-	_, err = conn.ReadUntilTimeout(prompt, 10*time.Second)
+	_, err = readUntilTimeout(ctx, conn, prompt, 10*time.Second)
 	if err != nil {
 		return "", fmt.Errorf("creating new login session for %s: %v", username, err)
 	}
 
 	conn.Write([]byte(password))
 
-	out, err := conn.ReadUntilTimeout("****\n", 10*time.Second)
+	out, err := readUntilTimeout(ctx, conn, "****\n", 10*time.Second)
 	if err != nil {
 		return "", fmt.Errorf("failed authentication for %s: %v", username, err)
 	}
@@ -186,7 +354,7 @@ func login(conn *Conn, username, password string, cfg *Config) (string, error) {
 	re := regexp.MustCompile("\\*\\*\\*\\* ([a-zA-Z]+) is already logged in - kicking them out\\.")
 	loggedin := re.FindSubmatch(out)
 	if loggedin != nil && len(loggedin) > 0 {
-		out, err = conn.ReadUntilTimeout("****\n", 10*time.Second)
+		out, err = readUntilTimeout(ctx, conn, "****\n", 10*time.Second)
 		if err != nil {
 			return "", fmt.Errorf("failed authentication for %s: %v", username, err)
 		}
@@ -202,3 +370,11 @@ func login(conn *Conn, username, password string, cfg *Config) (string, error) {
 
 	return "", fmt.Errorf("invalid password for %s", username)
 }
+
+// readUntilTimeout reads until the given prompt, giving up after the timeout
+// or as soon as the parent context is done
+func readUntilTimeout(ctx context.Context, conn *Conn, prompt string, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return conn.ReadUntilContext(ctx, prompt)
+}
@@ -0,0 +1,194 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"sync"
+	"time"
+)
+
+// GameClock keeps the clocks of a game running between the snapshots that
+// come with every GameMove, counting down the clock of the side to move
+type GameClock struct {
+	mu   sync.Mutex
+	game uint32
+	// remaining time of both players when the clocks were last set
+	white, black time.Duration
+	inc          time.Duration
+	turn         string
+	ticking      bool
+	since        time.Time
+	flag         *time.Timer
+	onFlag       []func(color string)
+}
+
+// NewGameClock creates a stopped clock for the given game. The clock must be
+// fed with the messages received from the server, for instance by registering
+// its Update method with Client.OnEvent
+func NewGameClock(game uint32) *GameClock {
+	return &GameClock{
+		game: game,
+		turn: "W",
+	}
+}
+
+// OnFlag registers a callback invoked when the clock of the given color
+// ("W" or "B") is predicted to run out, so that the client can claim a win on
+// time with the flag command
+func (c *GameClock) OnFlag(fn func(color string)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onFlag = append(c.onFlag, fn)
+}
+
+// Update sets the clocks from a GameMove of the game and stops them when the
+// game ends; other messages are ignored
+func (c *GameClock) Update(msg interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch m := msg.(type) {
+	case *GameMove:
+		if m.GameId != c.game {
+			return
+		}
+		unit := time.Second
+		if m.Ms {
+			unit = time.Millisecond
+		}
+		c.white = time.Duration(m.WhiteTime) * unit
+		c.black = time.Duration(m.BlackTime) * unit
+		c.inc = time.Duration(m.Inc) * time.Second
+		// the lag of the player who just moved was charged to their clock
+		// by the server, so it is credited back to them once
+		lag := time.Duration(m.Lag) * time.Millisecond
+		if m.Turn == "W" {
+			c.black += lag
+		} else {
+			c.white += lag
+		}
+		c.turn = m.Turn
+		c.ticking = m.ClockTicking
+		c.since = time.Now()
+		c.schedule()
+	case *GameEnd:
+		if m.GameId != c.game {
+			return
+		}
+		c.stop()
+	}
+}
+
+// Press stops the clock of the side to move, adds the increment to it and
+// starts the clock of the opponent, without waiting for the server to
+// confirm the move
+func (c *GameClock) Press() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.ticking {
+		return
+	}
+	c.white, c.black = c.remaining()
+	if c.turn == "W" {
+		c.white += c.inc
+		c.turn = "B"
+	} else {
+		c.black += c.inc
+		c.turn = "W"
+	}
+	c.since = time.Now()
+	c.schedule()
+}
+
+// Stop stops the clocks
+func (c *GameClock) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stop()
+}
+
+// Remaining returns the current time left to both players
+func (c *GameClock) Remaining() (white, black time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remaining()
+}
+
+// Turn returns the color ("W" or "B") whose clock is running, or would be if
+// the clocks were ticking
+func (c *GameClock) Turn() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.turn
+}
+
+// Ticking reports whether the clocks are running
+func (c *GameClock) Ticking() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ticking
+}
+
+// remaining returns the time left to both players; the caller must hold the lock
+func (c *GameClock) remaining() (time.Duration, time.Duration) {
+	white, black := c.white, c.black
+	if c.ticking {
+		elapsed := time.Since(c.since)
+		if c.turn == "W" {
+			white -= elapsed
+		} else {
+			black -= elapsed
+		}
+	}
+	return white, black
+}
+
+// stop stops the clocks and the flag timer; the caller must hold the lock
+func (c *GameClock) stop() {
+	c.white, c.black = c.remaining()
+	c.ticking = false
+	if c.flag != nil {
+		c.flag.Stop()
+		c.flag = nil
+	}
+}
+
+// schedule arms the flag timer for the side to move; the caller must hold the lock
+func (c *GameClock) schedule() {
+	if c.flag != nil {
+		c.flag.Stop()
+		c.flag = nil
+	}
+	if !c.ticking {
+		return
+	}
+
+	white, black := c.remaining()
+	left := white
+	if c.turn == "B" {
+		left = black
+	}
+	if left < 0 {
+		left = 0
+	}
+
+	turn := c.turn
+	var timer *time.Timer
+	timer = time.AfterFunc(left, func() {
+		c.mu.Lock()
+		if c.flag != timer {
+			// the clocks were set again in the meantime
+			c.mu.Unlock()
+			return
+		}
+		c.flag = nil
+		callbacks := c.onFlag
+		c.mu.Unlock()
+		for _, fn := range callbacks {
+			fn(turn)
+		}
+	})
+	c.flag = timer
+}
@@ -0,0 +1,55 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"testing"
+	"time"
+)
+
+// the lag field of style12 is the network lag of the player who made the last
+// move, which is credited to their clock without running the other one down
+func TestGameClockCreditsLagToMover(t *testing.T) {
+	const board = "<12> rnbqkbnr pppppppp -------- -------- ----P--- -------- PPPP-PPP RNBQKBNR B 4 1 1 1 1 0 7 Alice Bob -1 3 0 39 39 170000 175000 1 P/e2-e4 (0:05.000) e4 0 1 5000"
+
+	c := NewGameClock(7)
+	defer c.Stop()
+	for _, msg := range decodeMessages([]byte(board)) {
+		c.Update(msg)
+	}
+	white, black := c.Remaining()
+	if white != 175*time.Second {
+		t.Errorf("got white time %v, want 2m55s", white)
+	}
+	if black > 175*time.Second || black < 174*time.Second {
+		t.Errorf("got black time %v, want about 2m55s", black)
+	}
+}
+
+func TestGameClockCreditsLagOnce(t *testing.T) {
+	const board = "<12> rnbqkbnr pppp-ppp -------- ----p--- ----P--- -------- PPPP-PPP RNBQKBNR W 4 1 1 1 1 0 7 Alice Bob 1 3 0 39 39 170000 172000 2 P/e7-e5 (0:03.000) e5 0 1 2000"
+
+	c := NewGameClock(7)
+	defer c.Stop()
+	for _, msg := range decodeMessages([]byte(board)) {
+		c.Update(msg)
+	}
+	time.Sleep(50 * time.Millisecond)
+	white, black := c.Remaining()
+	if black != 174*time.Second {
+		t.Errorf("got black time %v, want 2m54s", black)
+	}
+	if white > 170*time.Second-50*time.Millisecond || white < 169*time.Second {
+		t.Errorf("got white time %v, want a little less than 2m50s", white)
+	}
+
+	// pressing the clock does not credit the lag again
+	c.Press()
+	white, black = c.Remaining()
+	if black > 174*time.Second || black < 173*time.Second {
+		t.Errorf("got black time %v after the press, want about 2m54s", black)
+	}
+}
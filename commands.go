@@ -0,0 +1,337 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+var handleRE *regexp.Regexp
+
+func init() {
+	// handles are made of up to 17 letters
+	handleRE = regexp.MustCompile(`^[a-zA-Z]{1,17}$`)
+}
+
+// Color is the color requested for a game
+type Color string
+
+// colors that can be requested for a game
+const (
+	ColorAny   Color = ""
+	ColorWhite Color = "white"
+	ColorBlack Color = "black"
+)
+
+// Variant is a variant of chess, as named in match and seek commands
+type Variant string
+
+// variants of chess supported by the server
+const (
+	VariantStandard   Variant = ""
+	VariantCrazyhouse Variant = "crazyhouse"
+	VariantBughouse   Variant = "bughouse"
+	VariantSuicide    Variant = "suicide"
+	VariantLosers     Variant = "losers"
+	VariantAtomic     Variant = "atomic"
+	VariantChess960   Variant = "wild fr"
+)
+
+// the boards of wild chess
+var wildBoards = map[string]bool{
+	"0": true, "1": true, "2": true, "3": true, "4": true, "5": true, "8": true, "8a": true, "fr": true,
+}
+
+// Wild returns the variant of wild chess played on the given board, e.g. 5 or fr
+func Wild(board string) Variant {
+	return Variant("wild " + board)
+}
+
+func (v Variant) validate() error {
+	switch v {
+	case VariantStandard, VariantCrazyhouse, VariantBughouse, VariantSuicide, VariantLosers, VariantAtomic:
+		return nil
+	}
+	if board := strings.TrimPrefix(string(v), "wild "); board != string(v) && wildBoards[board] {
+		return nil
+	}
+	return errors.Errorf("unknown variant %q", string(v))
+}
+
+func (c Color) validate() error {
+	switch c {
+	case ColorAny, ColorWhite, ColorBlack:
+		return nil
+	}
+	return errors.Errorf("unknown color %q", string(c))
+}
+
+// TimeControl is the initial time, in minutes, and the increment, in seconds,
+// of a game. The zero value is an untimed game
+type TimeControl struct {
+	Time uint32
+	Inc  uint32
+}
+
+func (tc TimeControl) validate() error {
+	if tc.Time > 999 || tc.Inc > 999 {
+		return errors.Errorf("invalid time control %d %d", tc.Time, tc.Inc)
+	}
+	return nil
+}
+
+// RatingRange is a range of ratings, inclusive. The zero value accepts any rating
+type RatingRange struct {
+	Min uint32
+	Max uint32
+}
+
+func (rr RatingRange) validate() error {
+	if rr.Min > rr.Max || rr.Max > 9999 {
+		return errors.Errorf("invalid rating range %d-%d", rr.Min, rr.Max)
+	}
+	return nil
+}
+
+// MatchOptions are the parameters of a match request
+type MatchOptions struct {
+	TimeControl TimeControl
+	Rated       bool
+	Color       Color
+	Variant     Variant
+}
+
+func (o *MatchOptions) validate() error {
+	if err := o.TimeControl.validate(); err != nil {
+		return err
+	}
+	if err := o.Color.validate(); err != nil {
+		return err
+	}
+	return o.Variant.validate()
+}
+
+// SeekOptions are the parameters of a seek ad
+type SeekOptions struct {
+	TimeControl TimeControl
+	Rated       bool
+	Color       Color
+	Variant     Variant
+	RatingRange RatingRange
+	// Manual lets us accept or decline players answering the seek ad, instead
+	// of starting the game automatically
+	Manual bool
+	// Formula restricts the seek ad to players matching our formula
+	Formula bool
+}
+
+func (o *SeekOptions) validate() error {
+	if err := o.TimeControl.validate(); err != nil {
+		return err
+	}
+	if err := o.Color.validate(); err != nil {
+		return err
+	}
+	if err := o.Variant.validate(); err != nil {
+		return err
+	}
+	if o.RatingRange == (RatingRange{}) {
+		return nil
+	}
+	return o.RatingRange.validate()
+}
+
+// ratedString returns the rated or unrated keyword of match and seek commands
+func ratedString(rated bool) string {
+	if rated {
+		return "rated"
+	}
+	return "unrated"
+}
+
+// validateHandle checks that a handle is well formed
+func validateHandle(handle string) error {
+	if !handleRE.MatchString(handle) {
+		return errors.Errorf("invalid handle %q", handle)
+	}
+	return nil
+}
+
+// escape replaces newlines and other control characters, which would end a
+// command early or inject another one, with spaces
+func escape(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, text)
+}
+
+// sendf formats and sends a command
+func (client *Client) sendf(format string, args ...interface{}) error {
+	return client.Send([]byte(fmt.Sprintf(format, args...)))
+}
+
+// Match challenges a player to a game. With nil options, the server defaults
+// are used
+func (client *Client) Match(user string, opts *MatchOptions) error {
+	if err := validateHandle(user); err != nil {
+		return err
+	}
+	if opts == nil {
+		return client.sendf("match %s", user)
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	cmd := fmt.Sprintf("match %s %s %d %d", user, ratedString(opts.Rated), opts.TimeControl.Time, opts.TimeControl.Inc)
+	if opts.Color != ColorAny {
+		cmd += " " + string(opts.Color)
+	}
+	if opts.Variant != VariantStandard {
+		cmd += " " + string(opts.Variant)
+	}
+	return client.Send([]byte(cmd))
+}
+
+// Seek posts a seek ad. With nil options, the server defaults are used
+func (client *Client) Seek(opts *SeekOptions) error {
+	if opts == nil {
+		return client.sendf("seek")
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	cmd := fmt.Sprintf("seek %d %d %s", opts.TimeControl.Time, opts.TimeControl.Inc, ratedString(opts.Rated))
+	if opts.Color != ColorAny {
+		cmd += " " + string(opts.Color)
+	}
+	if opts.Variant != VariantStandard {
+		cmd += " " + string(opts.Variant)
+	}
+	if opts.Manual {
+		cmd += " manual"
+	}
+	if opts.Formula {
+		cmd += " formula"
+	}
+	if opts.RatingRange != (RatingRange{}) {
+		cmd += fmt.Sprintf(" %d-%d", opts.RatingRange.Min, opts.RatingRange.Max)
+	}
+	return client.Send([]byte(cmd))
+}
+
+// Play answers the seek ad with the given index
+func (client *Client) Play(index uint32) error {
+	return client.sendf("play %d", index)
+}
+
+// Observe starts observing the given game
+func (client *Client) Observe(game uint32) error {
+	return client.sendf("observe %d", game)
+}
+
+// Unobserve stops observing the given game, or all games if game is 0
+func (client *Client) Unobserve(game uint32) error {
+	if game == 0 {
+		return client.sendf("unobserve")
+	}
+	return client.sendf("unobserve %d", game)
+}
+
+// Tell sends a private message to a player
+func (client *Client) Tell(user, msg string) error {
+	if err := validateHandle(user); err != nil {
+		return err
+	}
+	return client.sendf("tell %s %s", user, escape(msg))
+}
+
+// ChannelTell sends a message to a channel
+func (client *Client) ChannelTell(channel uint32, msg string) error {
+	if channel > 255 {
+		return errors.Errorf("invalid channel %d", channel)
+	}
+	return client.sendf("tell %d %s", channel, escape(msg))
+}
+
+// Kibitz sends a message to the players and observers of our game
+func (client *Client) Kibitz(msg string) error {
+	return client.sendf("kibitz %s", escape(msg))
+}
+
+// Whisper sends a message to the observers of our game
+func (client *Client) Whisper(msg string) error {
+	return client.sendf("whisper %s", escape(msg))
+}
+
+// Resign resigns our game
+func (client *Client) Resign() error {
+	return client.sendf("resign")
+}
+
+// Draw offers or accepts a draw in our game
+func (client *Client) Draw() error {
+	return client.sendf("draw")
+}
+
+// Abort requests or agrees to abort our game
+func (client *Client) Abort() error {
+	return client.sendf("abort")
+}
+
+// Flag claims a win because the opponent has run out of time
+func (client *Client) Flag() error {
+	return client.sendf("flag")
+}
+
+// Takeback requests taking back the given number of half-moves
+func (client *Client) Takeback(n uint32) error {
+	if n == 0 {
+		return errors.New("takeback of no moves")
+	}
+	return client.sendf("takeback %d", n)
+}
+
+// Moves requests the movelist of the given game
+func (client *Client) Moves(game uint32) error {
+	return client.sendf("moves %d", game)
+}
+
+// Refresh requests the current position of the given game, or of our game if
+// game is 0
+func (client *Client) Refresh(game uint32) error {
+	if game == 0 {
+		return client.sendf("refresh")
+	}
+	return client.sendf("refresh %d", game)
+}
+
+// Examine starts examining the stored game of a player, identified by its
+// number or its journal slot, or a new game if user is empty
+func (client *Client) Examine(user, game string) error {
+	if user == "" {
+		return client.sendf("examine")
+	}
+	if err := validateHandle(user); err != nil {
+		return err
+	}
+	if game == "" {
+		return client.sendf("examine %s", user)
+	}
+	// journal slots are prefixed with %, e.g. %B
+	slot := strings.TrimPrefix(game, "%")
+	if slot == "" || strings.IndexFunc(slot, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) != -1 {
+		return errors.Errorf("invalid game %q", game)
+	}
+	return client.sendf("examine %s %s", user, game)
+}
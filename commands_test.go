@@ -0,0 +1,112 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTypedCommands(t *testing.T) {
+	conn, lines, done := dialWriter(t, "commands")
+	defer done()
+	client := &Client{config: &Config{DisableTimeseal: true}, conn: conn}
+
+	tests := []struct {
+		name string
+		cmd  func() error
+		// the command sent to the server, or empty if the arguments are rejected
+		want string
+	}{
+		{"match defaults", func() error { return client.Match("Alice", nil) }, "match Alice"},
+		{"match", func() error {
+			return client.Match("Alice", &MatchOptions{TimeControl: TimeControl{3, 0}, Rated: true, Color: ColorWhite, Variant: VariantCrazyhouse})
+		}, "match Alice rated 3 0 white crazyhouse"},
+		{"match chess960", func() error {
+			return client.Match("Bob", &MatchOptions{TimeControl: TimeControl{5, 2}, Variant: VariantChess960})
+		}, "match Bob unrated 5 2 wild fr"},
+		{"match wild", func() error {
+			return client.Match("Bob", &MatchOptions{TimeControl: TimeControl{2, 12}, Color: ColorBlack, Variant: Wild("5")})
+		}, "match Bob unrated 2 12 black wild 5"},
+		{"match invalid handle", func() error { return client.Match("Alice Bob", nil) }, ""},
+		{"match handle with a command", func() error { return client.Match("Alice\nquit", nil) }, ""},
+		{"match handle too long", func() error { return client.Match("Abcdefghijklmnopqr", nil) }, ""},
+		{"match invalid time", func() error {
+			return client.Match("Alice", &MatchOptions{TimeControl: TimeControl{1000, 0}})
+		}, ""},
+		{"match invalid color", func() error {
+			return client.Match("Alice", &MatchOptions{Color: "red"})
+		}, ""},
+		{"match unknown variant", func() error {
+			return client.Match("Alice", &MatchOptions{Variant: "shogi"})
+		}, ""},
+		{"match unknown wild board", func() error {
+			return client.Match("Alice", &MatchOptions{Variant: Wild("6")})
+		}, ""},
+		{"seek defaults", func() error { return client.Seek(nil) }, "seek"},
+		{"seek", func() error {
+			return client.Seek(&SeekOptions{TimeControl: TimeControl{15, 5}, Rated: true, Variant: VariantAtomic, Manual: true, Formula: true, RatingRange: RatingRange{1500, 2000}})
+		}, "seek 15 5 rated atomic manual formula 1500-2000"},
+		{"seek any rating", func() error {
+			return client.Seek(&SeekOptions{TimeControl: TimeControl{1, 0}, Color: ColorBlack})
+		}, "seek 1 0 unrated black"},
+		{"seek inverted rating range", func() error {
+			return client.Seek(&SeekOptions{RatingRange: RatingRange{2000, 1500}})
+		}, ""},
+		{"seek rating too high", func() error {
+			return client.Seek(&SeekOptions{RatingRange: RatingRange{0, 10000}})
+		}, ""},
+		{"seek invalid increment", func() error {
+			return client.Seek(&SeekOptions{TimeControl: TimeControl{5, 1000}})
+		}, ""},
+		{"play", func() error { return client.Play(12) }, "play 12"},
+		{"observe", func() error { return client.Observe(117) }, "observe 117"},
+		{"unobserve", func() error { return client.Unobserve(117) }, "unobserve 117"},
+		{"unobserve all", func() error { return client.Unobserve(0) }, "unobserve"},
+		{"tell", func() error { return client.Tell("Alice", "hi\nquit") }, "tell Alice hi quit"},
+		{"tell invalid handle", func() error { return client.Tell("Guest123", "hi") }, ""},
+		{"channel tell", func() error { return client.ChannelTell(50, "hello\rall") }, "tell 50 hello all"},
+		{"channel tell invalid channel", func() error { return client.ChannelTell(256, "hello") }, ""},
+		{"kibitz", func() error { return client.Kibitz("nice move") }, "kibitz nice move"},
+		{"whisper", func() error { return client.Whisper("blunder\x00") }, "whisper blunder"},
+		{"takeback", func() error { return client.Takeback(2) }, "takeback 2"},
+		{"takeback of no moves", func() error { return client.Takeback(0) }, ""},
+		{"refresh", func() error { return client.Refresh(0) }, "refresh"},
+		{"refresh game", func() error { return client.Refresh(7) }, "refresh 7"},
+		{"examine", func() error { return client.Examine("", "") }, "examine"},
+		{"examine player", func() error { return client.Examine("Alice", "") }, "examine Alice"},
+		{"examine journal slot", func() error { return client.Examine("Alice", "%B") }, "examine Alice %B"},
+		{"examine empty journal slot", func() error { return client.Examine("Alice", "%") }, ""},
+		{"examine game", func() error { return client.Examine("Alice", "B12") }, "examine Alice B12"},
+		{"examine game with a command", func() error { return client.Examine("Alice", "1;quit") }, ""},
+	}
+	for _, tt := range tests {
+		err := tt.cmd()
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("%s: accepted invalid arguments", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		select {
+		case line := <-lines:
+			if line != tt.want {
+				t.Errorf("%s: sent %q, want %q", tt.name, line, tt.want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("%s: nothing sent", tt.name)
+		}
+	}
+	select {
+	case line := <-lines:
+		t.Errorf("sent unexpected %q", line)
+	default:
+	}
+}
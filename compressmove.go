@@ -0,0 +1,203 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/freechessclub/icsgo/chess"
+	"github.com/pkg/errors"
+)
+
+var compressedMoveRE *regexp.Regexp
+
+func init() {
+	// <d1> 7 2 e5 e7e5 1234 178766
+	compressedMoveRE = regexp.MustCompile(`^<d1>\s+([0-9]+)\s+([0-9]+)\s+(\S+)\s+(\S+)\s+([0-9]+)\s+(\-?[0-9]+)`)
+}
+
+// values of the pieces in the material strength reported by style12
+var pieceValues = map[chess.PieceType]uint32{
+	chess.Pawn:   1,
+	chess.Knight: 3,
+	chess.Bishop: 3,
+	chess.Rook:   5,
+	chess.Queen:  9,
+}
+
+func decodeCompressedMove(msg []byte) *CompressedMove {
+	matches := compressedMoveRE.FindSubmatch(msg)
+	if matches == nil || len(matches) < 7 {
+		return nil
+	}
+	left, _ := strconv.Atoi(string(matches[6]))
+	return &CompressedMove{
+		GameId:    unsafeAtoi(matches[1]),
+		Ply:       unsafeAtoi(matches[2]),
+		Move:      string(matches[3]),
+		SmithMove: string(matches[4]),
+		MoveTime:  unsafeAtoi(matches[5]),
+		TimeLeft:  int32(left),
+	}
+}
+
+// compressedMoves expands compressed moves into full game moves, keeping the
+// latest position of every game
+type compressedMoves struct {
+	sync.Mutex
+	games map[uint32]*GameMove
+}
+
+// expand replaces the compressed moves that follow a known position with
+// the GameMove they lead to. Compressed moves that cannot be expanded, e.g.
+// after a missed move, are left as they are
+func (c *compressedMoves) expand(msgs []interface{}) {
+	c.Lock()
+	defer c.Unlock()
+	if c.games == nil {
+		c.games = make(map[uint32]*GameMove)
+	}
+
+	for i, msg := range msgs {
+		switch m := msg.(type) {
+		case *GameMove:
+			c.games[m.GameId] = m
+		case *GameEnd:
+			delete(c.games, m.GameId)
+		case *CompressedMove:
+			last, ok := c.games[m.GameId]
+			if !ok {
+				continue
+			}
+			next, err := expandMove(last, m)
+			if err != nil {
+				delete(c.games, m.GameId)
+				continue
+			}
+			c.games[m.GameId] = next
+			msgs[i] = next
+		}
+	}
+}
+
+// ply returns the number of half-moves played in the position of a GameMove
+func ply(m *GameMove) uint32 {
+	p := (m.MoveNo - 1) * 2
+	if m.Turn == "B" {
+		p++
+	}
+	return p
+}
+
+// expandMove applies a compressed move to the previous position of its game
+func expandMove(last *GameMove, d *CompressedMove) (*GameMove, error) {
+	if d.Ply != ply(last)+1 {
+		return nil, errors.Errorf("missed moves before half-move %d of game %d", d.Ply, d.GameId)
+	}
+	pos, err := last.Position()
+	if err != nil {
+		return nil, err
+	}
+
+	// Smith notation adds the captured piece, castling and en passant markers
+	// and the promotion piece, in uppercase, to the squares of the move
+	if len(d.SmithMove) < 4 {
+		return nil, errors.Errorf("invalid move %q", d.SmithMove)
+	}
+	uci := d.SmithMove[:4]
+	if i := strings.IndexAny(d.SmithMove[4:], "NBRQ"); i != -1 {
+		uci += strings.ToLower(d.SmithMove[4+i : 5+i])
+	}
+	mv, err := pos.ParseUCI(uci)
+	if err != nil {
+		return nil, err
+	}
+	next, err := pos.Play(mv)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &GameMove{
+		GameId:           last.GameId,
+		WhiteName:        last.WhiteName,
+		BlackName:        last.BlackName,
+		Role:             last.Role,
+		Time:             last.Time,
+		Inc:              last.Inc,
+		WhiteTime:        last.WhiteTime,
+		BlackTime:        last.BlackTime,
+		Turn:             strings.ToUpper(next.Turn.String()),
+		MoveNo:           uint32(next.FullmoveNumber),
+		Move:             d.Move,
+		VerboseMove:      verboseMove(pos, mv),
+		MoveTime:         d.MoveTime,
+		Ms:               true,
+		Flip:             last.Flip,
+		ClockTicking:     true,
+		DoublePawnPush:   -1,
+		WhiteCastleShort: next.Castling&chess.WhiteKingSide != 0,
+		WhiteCastleLong:  next.Castling&chess.WhiteQueenSide != 0,
+		BlackCastleShort: next.Castling&chess.BlackKingSide != 0,
+		BlackCastleLong:  next.Castling&chess.BlackQueenSide != 0,
+		HalfmoveClock:    uint32(next.HalfmoveClock),
+		Fen:              next.FEN(),
+	}
+	if next.EnPassant != chess.NoSquare {
+		m.DoublePawnPush = int32(next.EnPassant.File())
+	}
+
+	switch m.Role {
+	case RoleMyMove:
+		m.Role = RoleOpponentMove
+	case RoleOpponentMove:
+		m.Role = RoleMyMove
+	}
+
+	if !last.Ms {
+		m.WhiteTime *= 1000
+		m.BlackTime *= 1000
+	}
+	if pos.Turn == chess.White {
+		m.WhiteTime = d.TimeLeft
+	} else {
+		m.BlackTime = d.TimeLeft
+	}
+
+	for sq := chess.Square(0); sq < 64; sq++ {
+		pc := next.Piece(sq)
+		if pc == chess.NoPiece {
+			continue
+		}
+		if pc.Color() == chess.White {
+			m.WhiteStrength += pieceValues[pc.Type()]
+		} else {
+			m.BlackStrength += pieceValues[pc.Type()]
+		}
+	}
+	return m, nil
+}
+
+// verboseMove returns a move in the verbose notation of style12, e.g. P/e2-e4
+// or o-o
+func verboseMove(pos *chess.Position, m chess.Move) string {
+	pc := pos.Piece(m.From)
+	if pc.Type() == chess.King {
+		switch m.To.File() - m.From.File() {
+		case 2:
+			return "o-o"
+		case -2:
+			return "o-o-o"
+		}
+	}
+	s := pc.Type().String() + "/" + m.From.String() + "-" + m.To.String()
+	if m.Promotion != chess.NoPieceType {
+		s += "=" + m.Promotion.String()
+	}
+	return s
+}
@@ -7,10 +7,14 @@ package icsgo
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
+	"net"
+	"net/url"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/ziutek/telnet"
 )
 
@@ -24,27 +28,71 @@ type Conn struct {
 	timeseal bool
 	// whether debug/verbose logging is enabled
 	debug bool
+	// whether the server does not wrap long lines, set with the nowrap ivariable
+	nowrap bool
+	// frames the server output returned by ReadMessage
+	reader messageReader
+	// messages waiting to be written
+	queue *writeQueue
 	// the underlying telnet connection
 	conn *telnet.Conn
 }
 
 // Dial creates a new connection
 func Dial(addr string, retries int, timeout time.Duration, timeseal, debug bool) (*Conn, error) {
+	return DialContext(context.Background(), addr, retries, timeout, timeseal, debug)
+}
+
+// DialContext creates a new connection using the provided context. The context
+// bounds the whole dial, including all retry attempts. The address is a URL
+// whose scheme selects the transport, e.g. telnet://freechess.org:5000 or
+// wss://example.org/ws; addresses without a scheme are telnet addresses
+func DialContext(ctx context.Context, addr string, retries int, timeout time.Duration, timeseal, debug bool) (*Conn, error) {
+	u, err := parseAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := getTransport(u.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	return dialTransport(ctx, transport, u, retries, timeout, timeseal, debug)
+}
+
+// dialTransport creates a new connection over the given transport
+func dialTransport(ctx context.Context, transport Transport, addr *url.URL, retries int, timeout time.Duration, timeseal, debug bool) (*Conn, error) {
 	connected := false
 
 	var conn *telnet.Conn
 	var err error
 
 	for attempts := 1; attempts <= retries && connected != true; attempts++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
 		log.Printf("connecting to ICS server %s (attempt %d of %d)...", addr, attempts, retries)
-		conn, err = telnet.DialTimeout("tcp", addr, timeout)
+		var nc net.Conn
+		nc, err = dialTimeout(ctx, transport, addr, timeout)
 		if err != nil {
 			timeout = time.Duration(float64(timeout) * 1.5)
 			continue
 		}
+		conn, err = telnet.NewConn(nc)
+		if err != nil {
+			nc.Close()
+			continue
+		}
 		connected = true
 	}
 
+	if ctx.Err() != nil {
+		if conn != nil {
+			conn.Close()
+		}
+		return nil, ctx.Err()
+	}
+
 	if err != nil || connected == false {
 		return nil, fmt.Errorf("connecting to server %s: %v", addr, err)
 	}
@@ -55,7 +103,9 @@ func Dial(addr string, retries int, timeout time.Duration, timeseal, debug bool)
 		timeseal: timeseal,
 		debug:    debug,
 		conn:     conn,
+		queue:    newWriteQueue(),
 	}
+	go c.writeLoop()
 
 	if timeseal {
 		c.Write([]byte(timesealHello))
@@ -64,13 +114,41 @@ func Dial(addr string, retries int, timeout time.Duration, timeseal, debug bool)
 	return c, nil
 }
 
+// dialTimeout dials the transport, giving up after the timeout
+func dialTimeout(ctx context.Context, transport Transport, addr *url.URL, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return transport.Dial(ctx, addr)
+}
+
 // ReadUntilTimeout reads messages from the connection until the given prompt is encountered
 // or until the given timeout duration has surpassed
 func (c *Conn) ReadUntilTimeout(prompt string, timeout time.Duration) ([]byte, error) {
-	c.conn.SetReadDeadline(time.Now().Add(timeout))
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return c.ReadUntilContext(ctx, prompt)
+}
 
+// ReadUntilContext reads messages from the connection until the given prompt is encountered.
+// The read is aborted as soon as the context is cancelled or its deadline expires, in which
+// case the context error is returned
+func (c *Conn) ReadUntilContext(ctx context.Context, prompt string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	deadline, _ := ctx.Deadline()
+	stop := c.interruptible(ctx, deadline)
 	bs, err := c.conn.ReadUntil(prompt)
+	stop()
+
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if ne, ok := err.(net.Error); ok && ne.Timeout() && !deadline.IsZero() && !time.Now().Before(deadline) {
+			return nil, context.DeadlineExceeded
+		}
 		return nil, err
 	}
 
@@ -79,51 +157,92 @@ func (c *Conn) ReadUntilTimeout(prompt string, timeout time.Duration) ([]byte, e
 	}
 
 	if c.timeseal {
-		for {
-			i := bytes.Index(bs, []byte{'[', 'G', ']', 0x00})
-			if i == -1 {
-				break
-			}
-			c.Write([]byte{0x02, 0x39})
-			bs = append(bs[:i], bs[i+4:]...)
-		}
+		bs = c.ackPings(bs)
 	}
 
-	bs = bytes.Replace(bs, []byte("\u0007"), []byte{}, -1)
-	bs = bytes.Replace(bs, []byte("\x00"), []byte{}, -1)
-	bs = bytes.Replace(bs, []byte("\\   "), []byte{}, -1)
-	bs = bytes.Replace(bs, []byte("\r"), []byte{}, -1)
-	bs = bytes.Replace(bs, []byte(prompt), []byte{}, -1)
+	bs = c.unwrap(clean(bs))
+	bs = stripPrompts(bs, prompt)
 	bs = bytes.TrimSpace(bs)
 
 	return bs, nil
 }
 
+// unwrap joins the long lines wrapped by the server, unless wrapping is off
+func (c *Conn) unwrap(bs []byte) []byte {
+	if c.nowrap {
+		return bs
+	}
+	return bytes.Replace(bs, []byte("\\   "), []byte{}, -1)
+}
+
+// interruptible sets the read deadline of the connection, a zero deadline
+// meaning that reads never time out, and unblocks pending reads as soon as
+// the context is done, until stop is called
+func (c *Conn) interruptible(ctx context.Context, deadline time.Time) (stop func()) {
+	c.conn.SetReadDeadline(deadline)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			c.conn.SetReadDeadline(time.Unix(1, 0))
+		case <-done:
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// stripPrompts removes the prompt ending server output read until it, and
+// the prompts at the start of lines, leaving the prompt text alone anywhere else
+func stripPrompts(bs []byte, prompt string) []byte {
+	if prompt == "" {
+		return bs
+	}
+	bs = bytes.TrimSuffix(bs, []byte(prompt))
+	lines := bytes.Split(bs, []byte("\n"))
+	for i, line := range lines {
+		for bytes.HasPrefix(line, []byte(prompt)) {
+			line = bytes.TrimPrefix(line[len(prompt):], []byte(" "))
+		}
+		lines[i] = line
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
 // ReadUntil reads messages from the connection until the given prompt is encountered
 func (c *Conn) ReadUntil(prompt string) ([]byte, error) {
 	return c.ReadUntilTimeout(prompt, 3600*time.Second)
 }
 
-// Write writes the given message on the open connection
+// Write writes the given message on the open connection. It waits for the
+// messages queued before it, and for the rate limit, if any
 func (c *Conn) Write(msg []byte) error {
-	if c.debug {
-		log.Printf("> %s", string(msg))
-	}
+	return c.enqueue(PriorityCommand, msg, false)
+}
 
-	if c.timeseal {
-		msg = encode(msg, len(msg))
+// WritePriority writes the given message on the open connection ahead of
+// the messages of lower priority waiting to be written
+func (c *Conn) WritePriority(msg []byte, p Priority) error {
+	if p == priorityAck {
+		return errors.Errorf("invalid priority %d", p)
 	}
-	return c.RawWrite(msg)
+	return c.enqueue(p, msg, false)
 }
 
-// RawWrite writes the given message on the open connection without timeseal encoding
+// RawWrite writes the given message on the open connection without timeseal
+// encoding, ahead of the queued messages and regardless of the rate limit
 func (c *Conn) RawWrite(msg []byte) error {
-	c.conn.SetWriteDeadline(time.Now().Add(20 * time.Second))
-	_, err := c.conn.Conn.Write(msg)
-	return err
+	return c.enqueue(priorityAck, msg, true)
 }
 
 // Close closes the connection to the ICS server
 func (c *Conn) Close() {
+	c.queue.close()
 	c.conn.Close()
 }
@@ -7,6 +7,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"log"
@@ -26,28 +27,21 @@ func main() {
 		log.Fatalf("error creating new FICS client: %v", err)
 	}
 
-	done := make(chan struct{})
-	go func() {
-		defer client.Destroy()
-		for {
-			select {
-			default:
-				msgs, err := client.Recv()
-				if err == io.EOF {
-					return
-				}
-				if err != nil {
-					log.Fatalf("error receiving server output: %v", err)
-					return
-				}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-				if msgs != nil {
-					fmt.Printf("%v\n", msgs)
-				}
-			case <-done:
-				return
-			}
+	client.OnEvent(func(msg interface{}) {
+		fmt.Printf("%v\n", msg)
+	})
+	client.OnError(func(err error) {
+		if err != io.EOF {
+			log.Fatalf("error receiving server output: %v", err)
 		}
+	})
+
+	go func() {
+		defer client.Destroy()
+		client.Run(ctx)
 	}()
 
 	reader := bufio.NewReader(os.Stdin)
@@ -59,9 +53,9 @@ func main() {
 			log.Fatalf("error reading console input: %v", err)
 		}
 
-		err = client.Send(cmd)
+		err = client.Send([]byte(cmd))
 		if err != nil || cmd == "exit\n" {
-			close(done)
+			cancel()
 			break
 		}
 	}
@@ -0,0 +1,92 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var gameInfoRE *regexp.Regexp
+
+func init() {
+	// <g1> 1 p=0 t=blitz r=1 u=1,1 it=5,5 i=8,8 pt=0 rt=1586E,2100  ts=1,0
+	gameInfoRE = regexp.MustCompile(`^<g1>\s+([0-9]+)\s+(.*)$`)
+}
+
+// splitPair splits a value of the form white,black
+func splitPair(s string) (string, string) {
+	p := strings.SplitN(s, ",", 2)
+	if len(p) < 2 {
+		return p[0], ""
+	}
+	return p[0], p[1]
+}
+
+func decodeGameInfo(msg []byte) *GameInfo {
+	matches := gameInfoRE.FindSubmatch(msg)
+	if matches == nil || len(matches) < 3 {
+		return nil
+	}
+
+	kv := parseKeyValues(matches[2])
+	info := &GameInfo{
+		GameId:      unsafeAtoi(matches[1]),
+		Private:     kv["p"] == "1",
+		Type:        kv["t"],
+		Rated:       kv["r"] == "1",
+		PartnerGame: unsafeAtoi([]byte(kv["pt"])),
+	}
+
+	w, b := splitPair(kv["u"])
+	info.WhiteRegistered, info.BlackRegistered = w == "1", b == "1"
+	w, b = splitPair(kv["it"])
+	info.WhiteInitialTime, info.BlackInitialTime = unsafeAtoi([]byte(w)), unsafeAtoi([]byte(b))
+	w, b = splitPair(kv["i"])
+	info.WhiteInc, info.BlackInc = unsafeAtoi([]byte(w)), unsafeAtoi([]byte(b))
+	w, b = splitPair(kv["rt"])
+	info.WhiteRating, info.WhiteRatingFlag = parseRating(w)
+	info.BlackRating, info.BlackRatingFlag = parseRating(b)
+	w, b = splitPair(kv["ts"])
+	info.WhiteTimeseal, info.BlackTimeseal = w == "1", b == "1"
+	return info
+}
+
+// gameInfos attaches game info lines to the start of their games
+type gameInfos struct {
+	sync.Mutex
+	// game info lines of games that have not started yet
+	pending map[uint32]*GameInfo
+}
+
+// merge attaches game info lines to the game start messages of the same
+// game, keeping the lines that arrive first until their game starts
+func (g *gameInfos) merge(msgs []interface{}) {
+	g.Lock()
+	defer g.Unlock()
+	if g.pending == nil {
+		g.pending = make(map[uint32]*GameInfo)
+	}
+
+	for _, msg := range msgs {
+		switch m := msg.(type) {
+		case *GameInfo:
+			g.pending[m.GameId] = m
+		case *GameEnd:
+			delete(g.pending, m.GameId)
+		}
+	}
+
+	for _, msg := range msgs {
+		if m, ok := msg.(*GameStart); ok && m.Info == nil {
+			if info, ok := g.pending[m.GameId]; ok {
+				m.Info = info
+				delete(g.pending, m.GameId)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeGameInfo(t *testing.T) {
+	tests := []struct {
+		line string
+		want *GameInfo
+	}{
+		{
+			"<g1> 1 p=0 t=blitz r=1 u=1,1 it=5,5 i=8,8 pt=0 rt=1586E,2100  ts=1,0",
+			&GameInfo{
+				GameId:           1,
+				Type:             "blitz",
+				Rated:            true,
+				WhiteRegistered:  true,
+				BlackRegistered:  true,
+				WhiteInitialTime: 5,
+				BlackInitialTime: 5,
+				WhiteInc:         8,
+				BlackInc:         8,
+				WhiteRating:      1586,
+				WhiteRatingFlag:  "E",
+				BlackRating:      2100,
+				WhiteTimeseal:    true,
+			},
+		},
+		{
+			"<g1> 57 p=1 t=crazyhouse r=0 u=0,1 it=3,2 i=0,12 pt=0 rt=0P,1720  ts=0,1",
+			&GameInfo{
+				GameId:           57,
+				Private:          true,
+				Type:             "crazyhouse",
+				BlackRegistered:  true,
+				WhiteInitialTime: 3,
+				BlackInitialTime: 2,
+				BlackInc:         12,
+				WhiteRatingFlag:  "P",
+				BlackRating:      1720,
+				BlackTimeseal:    true,
+			},
+		},
+		{
+			"<g1> 104 p=0 t=bughouse r=1 u=1,1 it=2,2 i=0,0 pt=103 rt=1905,1877  ts=1,1",
+			&GameInfo{
+				GameId:           104,
+				Type:             "bughouse",
+				Rated:            true,
+				WhiteRegistered:  true,
+				BlackRegistered:  true,
+				WhiteInitialTime: 2,
+				BlackInitialTime: 2,
+				PartnerGame:      103,
+				WhiteRating:      1905,
+				BlackRating:      1877,
+				WhiteTimeseal:    true,
+				BlackTimeseal:    true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		got, ok := decodeOne(t, tt.line).(*GameInfo)
+		if !ok || !proto.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestGameInfoAttachedToGameStart(t *testing.T) {
+	var g gameInfos
+
+	// the game info line may arrive before the start of its game
+	info := decodeOne(t, "<g1> 117 p=0 t=blitz r=0 u=1,0 it=3,3 i=0,0 pt=0 rt=1500,0P  ts=1,1")
+	g.merge([]interface{}{info})
+
+	start := decodeOne(t, "{Game 117 (Alice vs. GuestMDPS) Creating unrated blitz match.}").(*GameStart)
+	g.merge([]interface{}{start})
+	if start.Info == nil || start.Info.GameId != 117 || start.Info.Type != "blitz" {
+		t.Fatalf("got info %v, want the info of game 117", start.Info)
+	}
+
+	// or in the same batch of messages
+	msgs := decodeMessages([]byte("{Game 118 (Bob vs. Carol) Creating rated crazyhouse match.}\n" +
+		"<g1> 118 p=0 t=crazyhouse r=1 u=1,1 it=2,2 i=0,0 pt=0 rt=1810,1765  ts=1,1"))
+	g.merge(msgs)
+	start, ok := msgs[0].(*GameStart)
+	if !ok || start.Info == nil || start.Info.Type != "crazyhouse" {
+		t.Fatalf("got %v, want the start of game 118 with its info", msgs[0])
+	}
+
+	// the info of a game that ended without starting is forgotten
+	g.merge([]interface{}{decodeOne(t, "<g1> 119 p=0 t=blitz r=0 u=1,1 it=3,3 i=0,0 pt=0 rt=1500,1500  ts=1,1")})
+	g.merge([]interface{}{&GameEnd{GameId: 119}})
+	if len(g.pending) != 0 {
+		t.Errorf("%d game info lines pending, want 0", len(g.pending))
+	}
+}
@@ -3,6 +3,7 @@ module github.com/freechessclub/icsgo
 go 1.12
 
 require (
+	github.com/gorilla/websocket v1.4.2
 	github.com/pkg/errors v0.9.1
 	github.com/ziutek/telnet v0.0.0-20180329124119-c3b780dc415b
 	google.golang.org/protobuf v1.27.1
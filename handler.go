@@ -0,0 +1,211 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// handlers holds the callbacks registered on a client
+type handlers struct {
+	sync.RWMutex
+	gameStart   []func(*GameStart)
+	gameMove    []func(*GameMove)
+	gameEnd     []func(*GameEnd)
+	channelTell []func(*ChannelTell)
+	privateTell []func(*PrivateTell)
+	partnerTell []func(*PartnerTell)
+	message     []func(*Message)
+	event       []func(interface{})
+	err         []func(error)
+}
+
+// OnGameStart registers a callback invoked for every game start message
+func (client *Client) OnGameStart(fn func(*GameStart)) {
+	client.handlers.Lock()
+	defer client.handlers.Unlock()
+	client.handlers.gameStart = append(client.handlers.gameStart, fn)
+}
+
+// OnGameMove registers a callback invoked for every game move (style12) message
+func (client *Client) OnGameMove(fn func(*GameMove)) {
+	client.handlers.Lock()
+	defer client.handlers.Unlock()
+	client.handlers.gameMove = append(client.handlers.gameMove, fn)
+}
+
+// OnGameEnd registers a callback invoked for every game end message
+func (client *Client) OnGameEnd(fn func(*GameEnd)) {
+	client.handlers.Lock()
+	defer client.handlers.Unlock()
+	client.handlers.gameEnd = append(client.handlers.gameEnd, fn)
+}
+
+// OnChannelTell registers a callback invoked for every channel tell, kibitz and whisper
+func (client *Client) OnChannelTell(fn func(*ChannelTell)) {
+	client.handlers.Lock()
+	defer client.handlers.Unlock()
+	client.handlers.channelTell = append(client.handlers.channelTell, fn)
+}
+
+// OnPrivateTell registers a callback invoked for every private tell
+func (client *Client) OnPrivateTell(fn func(*PrivateTell)) {
+	client.handlers.Lock()
+	defer client.handlers.Unlock()
+	client.handlers.privateTell = append(client.handlers.privateTell, fn)
+}
+
+// OnPartnerTell registers a callback invoked for every tell from our bughouse partner
+func (client *Client) OnPartnerTell(fn func(*PartnerTell)) {
+	client.handlers.Lock()
+	defer client.handlers.Unlock()
+	client.handlers.partnerTell = append(client.handlers.partnerTell, fn)
+}
+
+// OnMessage registers a callback invoked for every generic server message
+func (client *Client) OnMessage(fn func(*Message)) {
+	client.handlers.Lock()
+	defer client.handlers.Unlock()
+	client.handlers.message = append(client.handlers.message, fn)
+}
+
+// OnEvent registers a catch-all callback invoked for every message received
+// from the server, after any typed callbacks for that message
+func (client *Client) OnEvent(fn func(interface{})) {
+	client.handlers.Lock()
+	defer client.handlers.Unlock()
+	client.handlers.event = append(client.handlers.event, fn)
+}
+
+// OnError registers a callback invoked once when Run stops because reading
+// from the server failed
+func (client *Client) OnError(fn func(error)) {
+	client.handlers.Lock()
+	defer client.handlers.Unlock()
+	client.handlers.err = append(client.handlers.err, fn)
+}
+
+// Run reads messages from the ICS server and dispatches them to the registered
+// callbacks, in the order they were received, until the context is done or a
+// read fails. Callbacks are invoked from the goroutine calling Run, so a slow
+// callback delays the delivery of subsequent messages. Every message is also
+// published to the channels returned by Subscribe. If Config.Reconnect is set,
+// a dropped connection is restored instead of stopping Run, and the session
+// is announced by Disconnected and Reconnected events
+func (client *Client) Run(ctx context.Context) error {
+	client.runMu.Lock()
+	if client.running {
+		client.runMu.Unlock()
+		return errors.New("client is already running")
+	}
+	client.running = true
+	client.runMu.Unlock()
+
+	defer func() {
+		client.bus.closeAll(false)
+		client.runMu.Lock()
+		client.running = false
+		client.runMu.Unlock()
+	}()
+
+	for {
+		msgs, err := client.RecvContext(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if client.config.Reconnect && !client.isClosed() {
+				if err = client.reconnect(ctx, err); err == nil {
+					continue
+				}
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+			}
+			client.dispatchError(err)
+			return err
+		}
+
+		for _, msg := range msgs {
+			client.session.update(msg)
+			client.emit(ctx, msg)
+		}
+	}
+}
+
+// emit delivers a message to the registered callbacks and subscribers
+func (client *Client) emit(ctx context.Context, msg interface{}) {
+	client.dispatch(msg)
+	client.bus.publish(ctx, msg)
+}
+
+// dispatch invokes the callbacks registered for the given message
+func (client *Client) dispatch(msg interface{}) {
+	h := client.handlers.snapshot()
+
+	switch m := msg.(type) {
+	case *GameStart:
+		for _, fn := range h.gameStart {
+			fn(m)
+		}
+	case *GameMove:
+		for _, fn := range h.gameMove {
+			fn(m)
+		}
+	case *GameEnd:
+		for _, fn := range h.gameEnd {
+			fn(m)
+		}
+	case *ChannelTell:
+		for _, fn := range h.channelTell {
+			fn(m)
+		}
+	case *PrivateTell:
+		for _, fn := range h.privateTell {
+			fn(m)
+		}
+	case *PartnerTell:
+		for _, fn := range h.partnerTell {
+			fn(m)
+		}
+	case *Message:
+		for _, fn := range h.message {
+			fn(m)
+		}
+	}
+
+	for _, fn := range h.event {
+		fn(msg)
+	}
+}
+
+// dispatchError invokes the error callbacks with the error that stopped Run
+func (client *Client) dispatchError(err error) {
+	for _, fn := range client.handlers.snapshot().err {
+		fn(err)
+	}
+}
+
+// snapshot returns a copy of the registered callbacks, so that they can be
+// invoked without holding the lock and may themselves register callbacks
+func (h *handlers) snapshot() handlers {
+	h.RLock()
+	defer h.RUnlock()
+	return handlers{
+		gameStart:   h.gameStart,
+		gameMove:    h.gameMove,
+		gameEnd:     h.gameEnd,
+		channelTell: h.channelTell,
+		privateTell: h.privateTell,
+		partnerTell: h.partnerTell,
+		message:     h.message,
+		event:       h.event,
+		err:         h.err,
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var holdingsRE *regexp.Regexp
+
+func init() {
+	// <b1> game 6 white [PNB] black [Q] <- BN
+	holdingsRE = regexp.MustCompile(`^<b1>\s+game\s+([0-9]+)\s+white\s+\[([A-Za-z]*)\]\s+black\s+\[([A-Za-z]*)\](?:\s+<-\s+([WB])([PNBRQKpnbrqk]))?`)
+}
+
+func decodeHoldings(msg []byte) *Holdings {
+	matches := holdingsRE.FindSubmatch(msg)
+	if matches == nil || len(matches) < 6 {
+		return nil
+	}
+
+	h := &Holdings{
+		GameId: unsafeAtoi(matches[1]),
+		White:  strings.ToUpper(string(matches[2])),
+		Black:  strings.ToUpper(string(matches[3])),
+	}
+	if len(matches[5]) > 0 {
+		if string(matches[4]) == "W" {
+			h.AddedPiece = strings.ToUpper(string(matches[5]))
+		} else {
+			h.AddedPiece = strings.ToLower(string(matches[5]))
+		}
+	}
+	return h
+}
+
+// fen returns the holdings in the bracket notation of the FEN
+func (h *Holdings) fen() string {
+	return h.White + strings.ToLower(h.Black)
+}
+
+// holdingsTracker attaches the pieces in hand to the moves of crazyhouse
+// and bughouse games
+type holdingsTracker struct {
+	sync.Mutex
+	// latest holdings of games in progress
+	games map[uint32]*Holdings
+}
+
+// merge attaches the holdings of a game to its moves. The server may send the
+// holdings before or after the style12 line they belong to, so the holdings
+// received alongside a move take precedence over those received earlier
+func (t *holdingsTracker) merge(msgs []interface{}) {
+	t.Lock()
+	defer t.Unlock()
+	if t.games == nil {
+		t.games = make(map[uint32]*Holdings)
+	}
+
+	latest := make(map[uint32]*Holdings)
+	for _, msg := range msgs {
+		switch m := msg.(type) {
+		case *Holdings:
+			latest[m.GameId] = m
+			t.games[m.GameId] = m
+		case *GameEnd:
+			delete(t.games, m.GameId)
+		}
+	}
+
+	for _, msg := range msgs {
+		m, ok := msg.(*GameMove)
+		if !ok {
+			continue
+		}
+		h, ok := latest[m.GameId]
+		if !ok {
+			if h, ok = t.games[m.GameId]; !ok {
+				continue
+			}
+		}
+		m.Holdings = h.fen()
+		if i := strings.IndexByte(m.Fen, ' '); i != -1 {
+			m.Fen = m.Fen[:i] + "[" + m.Holdings + "]" + m.Fen[i:]
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeHoldings(t *testing.T) {
+	tests := []struct {
+		line string
+		want *Holdings
+	}{
+		{"<b1> game 6 white [PNB] black [Q] <- BN", &Holdings{GameId: 6, White: "PNB", Black: "Q", AddedPiece: "n"}},
+		{"<b1> game 12 white [PP] black [nb] <- WP", &Holdings{GameId: 12, White: "PP", Black: "NB", AddedPiece: "P"}},
+		{"<b1> game 33 white [] black []", &Holdings{GameId: 33}},
+		{"<b1> game 104 white [] black [RQ] <- Bq", &Holdings{GameId: 104, Black: "RQ", AddedPiece: "q"}},
+	}
+	for _, tt := range tests {
+		got, ok := decodeOne(t, tt.line).(*Holdings)
+		if !ok || !proto.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestHoldingsAttachedToMoves(t *testing.T) {
+	var h holdingsTracker
+	const board = "<12> rnbqkb-r pppppppp -------- -------- ----P--- -------- PPPP-PPP RNBQKB-R B -1 1 1 1 1 0 6 Alice Bob 0 3 0 39 39 170 175 5 P/@@-e4 (0:03) P@e4 0 1 0"
+
+	// holdings received with the move
+	msgs := decodeMessages([]byte(board + "\n<b1> game 6 white [N] black [n] <- BN"))
+	h.merge(msgs)
+	m := msgs[0].(*GameMove)
+	if m.Holdings != "Nn" || m.Fen != "rnbqkb1r/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKB1R[Nn] b KQkq - 0 5" {
+		t.Errorf("got holdings %q and FEN %q", m.Holdings, m.Fen)
+	}
+
+	// holdings received earlier are kept for the following moves
+	m = decodeOne(t, board).(*GameMove)
+	h.merge([]interface{}{m})
+	if m.Holdings != "Nn" {
+		t.Errorf("got holdings %q, want Nn", m.Holdings)
+	}
+
+	// and forgotten at the end of the game
+	h.merge([]interface{}{&GameEnd{GameId: 6}})
+	m = decodeOne(t, board).(*GameMove)
+	h.merge([]interface{}{m})
+	if m.Holdings != "" {
+		t.Errorf("got holdings %q after the end of the game", m.Holdings)
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ivariablesRE   *regexp.Regexp
+	ivariableSetRE *regexp.Regexp
+)
+
+func init() {
+	// Interface variable settings of GuestABCD:
+	ivariablesRE = regexp.MustCompile(`Interface variable settings of [a-zA-Z]+:`)
+
+	// ms set.
+	ivariableSetRE = regexp.MustCompile(`(?m)^[a-z]+ (?:un)?set\.\s*$`)
+}
+
+// the identifier of the command locking the ivariables in block mode
+const lockBlockID = 1
+
+// defaultPrompt is the prompt of the server once defprompt is set
+const defaultPrompt = "fics%"
+
+// supportedIvariables are the ivariables whose output the client understands:
+// the messages they enable are decoded, defprompt changes the prompt ending
+// messages, nowrap stops the unwrapping of long lines, and the others only
+// change how the server behaves
+var supportedIvariables = map[string]bool{
+	"seekinfo":     true,
+	"seekremove":   true,
+	"pendinfo":     true,
+	"gameinfo":     true,
+	"ms":           true,
+	"nowrap":       true,
+	"defprompt":    true,
+	"startpos":     true,
+	"smartmove":    true,
+	"premove":      true,
+	"allresults":   true,
+	"pin":          true,
+	"compressmove": true,
+	"block":        true,
+	"lock":         true,
+}
+
+// negotiated is the outcome of setting the ivariables of a new connection
+type negotiated struct {
+	// the ivariables active on the connection
+	features map[string]bool
+	// server output read while negotiating that is not a reply to our commands
+	pending []byte
+	// the prompt ending the messages of the server
+	prompt string
+}
+
+// negotiate sets the ivariables of the configuration on a new connection,
+// checks that the server enabled them all and locks them. Block mode is
+// enabled last, just before locking, as it changes the format of replies.
+// The connection is then set up to read the output of the ivariables
+func negotiate(ctx context.Context, conn *Conn, cfg *Config) (*negotiated, error) {
+	n := &negotiated{
+		features: make(map[string]bool),
+		prompt:   cfg.ICSPrompt,
+	}
+
+	block := cfg.BlockMode
+	var vars []string
+	for _, v := range cfg.Ivariables {
+		if !supportedIvariables[v] {
+			return nil, errors.Errorf("ivariable %s is not supported", v)
+		}
+		switch v {
+		case "block":
+			block = true
+		case "lock":
+		case "defprompt":
+			n.prompt = defaultPrompt
+			vars = append(vars, v)
+		default:
+			vars = append(vars, v)
+		}
+	}
+
+	if len(vars) > 0 {
+		for _, v := range vars {
+			if err := writeCommand(conn, cfg, []byte("iset "+v+" 1"), PriorityCommand); err != nil {
+				return nil, errors.Wrapf(err, "failed to set ivariable %s", v)
+			}
+		}
+		if err := writeCommand(conn, cfg, []byte("ivariables"), PriorityCommand); err != nil {
+			return nil, errors.Wrap(err, "failed to query ivariables")
+		}
+
+		var enabled map[string]string
+		for {
+			out, err := readUntilTimeout(ctx, conn, n.prompt, 10*time.Second)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to query ivariables")
+			}
+			loc := ivariablesRE.FindIndex(out)
+			if loc == nil {
+				n.keep(ivariableSetRE.ReplaceAll(out, nil))
+				continue
+			}
+			n.keep(ivariableSetRE.ReplaceAll(out[:loc[0]], nil))
+			enabled = parseKeyValues(out[loc[1]:])
+			break
+		}
+
+		for _, v := range vars {
+			if enabled[v] != "1" {
+				return nil, errors.Errorf("server did not enable ivariable %s", v)
+			}
+			n.features[v] = true
+		}
+	}
+
+	if block {
+		if err := writeCommand(conn, cfg, []byte("iset block 1"), PriorityCommand); err != nil {
+			return nil, errors.Wrap(err, "failed to enable block mode")
+		}
+		n.features["block"] = true
+	}
+
+	if len(vars) > 0 {
+		if err := n.lock(ctx, conn, cfg, block); err != nil {
+			return nil, err
+		}
+	}
+	conn.nowrap = n.features["nowrap"]
+	return n, nil
+}
+
+// lock locks the ivariables, waiting for the server to confirm
+func (n *negotiated) lock(ctx context.Context, conn *Conn, cfg *Config, block bool) error {
+	cmd := []byte("iset lock 1")
+	if block {
+		cmd = append([]byte(strconv.Itoa(lockBlockID)+" "), cmd...)
+	}
+	if err := writeCommand(conn, cfg, cmd, PriorityCommand); err != nil {
+		return errors.Wrap(err, "failed to lock ivariables")
+	}
+
+	var b blocks
+	for {
+		out, err := readUntilTimeout(ctx, conn, n.prompt, 10*time.Second)
+		if err != nil {
+			return errors.Wrap(err, "failed to lock ivariables")
+		}
+		if !block {
+			if loc := ivariableSetRE.FindIndex(out); loc != nil {
+				n.keep(out[:loc[0]])
+				n.keep(out[loc[1]:])
+				break
+			}
+			n.keep(out)
+			continue
+		}
+
+		resps, rest := b.split(out)
+		n.keep(ivariableSetRE.ReplaceAll(rest, nil))
+		locked := false
+		for _, resp := range resps {
+			if resp.Id == lockBlockID {
+				locked = true
+			} else {
+				n.keep([]byte(resp.Body))
+			}
+		}
+		if locked {
+			break
+		}
+	}
+	n.features["lock"] = true
+	return nil
+}
+
+// keep holds on to server output to be decoded once the client is running
+func (n *negotiated) keep(out []byte) {
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
+		return
+	}
+	if len(n.pending) > 0 {
+		n.pending = append(n.pending, '\n')
+	}
+	n.pending = append(n.pending, out...)
+}
+
+// Features returns the ivariables of the configuration active on the
+// connection to the server, as negotiated at login
+func (client *Client) Features() map[string]bool {
+	client.connMu.RLock()
+	defer client.connMu.RUnlock()
+	features := make(map[string]bool, len(client.features))
+	for k, v := range client.features {
+		features[k] = v
+	}
+	return features
+}
+
+// hasFeature reports whether the given ivariable is active on the connection
+func (client *Client) hasFeature(name string) bool {
+	client.connMu.RLock()
+	defer client.connMu.RUnlock()
+	return client.features[name]
+}
+
+// prompt returns the prompt ending the messages of the server
+func (client *Client) prompt() string {
+	client.connMu.RLock()
+	defer client.connMu.RUnlock()
+	return client.icsPrompt
+}
+
+// takePending returns the server output read while negotiating the ivariables
+// of the connection, if it has not been returned yet
+func (client *Client) takePending() []byte {
+	client.connMu.Lock()
+	defer client.connMu.Unlock()
+	pending := client.pending
+	client.pending = nil
+	return pending
+}
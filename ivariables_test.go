@@ -0,0 +1,156 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeServer logs a guest in and answers the commands setting ivariables like
+// FICS does, over an in-memory connection
+func fakeServer(t *testing.T, name string) (*PipeListener, chan net.Conn) {
+	l, err := ListenPipe(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conns := make(chan net.Conn, 1)
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		r := bufio.NewReader(c)
+		io.WriteString(c, "login: ")
+		r.ReadString('\n')
+		io.WriteString(c, "Press return to enter the server as \"GuestABCD\":\n")
+		r.ReadString('\n')
+		io.WriteString(c, "**** Starting FICS session as GuestABCD(U) ****\n\nfics% ")
+
+		set := map[string]bool{}
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			fields := strings.Fields(line)
+			switch {
+			case len(fields) == 3 && fields[0] == "iset":
+				set[fields[1]] = true
+				io.WriteString(c, fields[1]+" set.\nfics% ")
+				if fields[1] == "lock" {
+					conns <- c
+					return
+				}
+			case len(fields) == 1 && fields[0] == "ivariables":
+				var vars []string
+				for _, v := range []string{"compressmove", "defprompt", "gameinfo", "lock", "ms", "nowrap", "pin", "seekinfo"} {
+					value := "0"
+					if set[v] {
+						value = "1"
+					}
+					vars = append(vars, v+"="+value)
+				}
+				io.WriteString(c, "Interface variable settings of GuestABCD:\n\n"+strings.Join(vars[:4], " ")+"\n"+strings.Join(vars[4:], " ")+"\n\nfics% ")
+			}
+		}
+	}()
+	return l, conns
+}
+
+func TestNegotiateOverPipe(t *testing.T) {
+	l, conns := fakeServer(t, "negotiate")
+	defer l.Close()
+
+	cfg := &Config{
+		DisableTimeseal:  true,
+		DisableKeepAlive: true,
+		Ivariables:       []string{"defprompt", "nowrap", "gameinfo"},
+	}
+	client, err := NewClient(cfg, "pipe://negotiate", "guest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := <-conns
+	defer server.Close()
+
+	want := map[string]bool{"defprompt": true, "nowrap": true, "gameinfo": true, "lock": true}
+	if got := client.Features(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got features %v, want %v", got, want)
+	}
+
+	// with nowrap, the server does not wrap lines, so nothing is unwrapped
+	const text = `Path: C:\   Users`
+	io.WriteString(server, text+"\nfics% ")
+	msg, err := client.getConn().ReadMessage(client.prompt())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(msg) != text {
+		t.Errorf("got message %q, want %q", msg, text)
+	}
+}
+
+func TestNegotiateRejectsUnsupportedIvariables(t *testing.T) {
+	l, conns := fakeServer(t, "unsupported")
+	defer l.Close()
+
+	cfg := &Config{
+		DisableTimeseal:  true,
+		DisableKeepAlive: true,
+		ConnRetries:      1,
+		Ivariables:       []string{"xml"},
+	}
+	if _, err := NewClient(cfg, "pipe://unsupported", "guest", ""); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("got error %v, want xml to be rejected", err)
+	}
+	select {
+	case <-conns:
+		t.Error("the ivariables were locked")
+	default:
+	}
+}
+
+func TestNegotiatedFeaturesChangeDecoding(t *testing.T) {
+	l, conns := fakeServer(t, "decoding")
+	defer l.Close()
+
+	cfg := &Config{
+		DisableTimeseal:  true,
+		DisableKeepAlive: true,
+		Ivariables:       []string{"ms", "pin"},
+	}
+	client, err := NewClient(cfg, "pipe://decoding", "guest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := <-conns
+	defer server.Close()
+
+	// the clocks are in milliseconds although the move time has no fraction
+	msgs := client.decode([]byte("<12> rnbqkbnr pppppppp -------- -------- -------- -------- PPPPPPPP RNBQKBNR W -1 1 1 1 1 0 7 Alice Bob 1 3 0 39 39 180000 180000 1 none (0:00) none 0 0 0"))
+	if len(msgs) != 1 {
+		t.Fatalf("decoded %d messages, want 1", len(msgs))
+	}
+	if m, ok := msgs[0].(*GameMove); !ok || !m.Ms {
+		t.Errorf("got %v, want a GameMove with clocks in milliseconds", msgs[0])
+	}
+
+	msgs = client.decode([]byte("<wa> Alice\n<wd> Bob"))
+	if len(msgs) != 2 {
+		t.Fatalf("decoded %d messages, want 2", len(msgs))
+	}
+	if m, ok := msgs[0].(*UserArrived); !ok || m.Handle != "Alice" {
+		t.Errorf("got %v, want the arrival of Alice", msgs[0])
+	}
+	if m, ok := msgs[1].(*UserDeparted); !ok || m.Handle != "Bob" {
+		t.Errorf("got %v, want the departure of Bob", msgs[1])
+	}
+}
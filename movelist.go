@@ -0,0 +1,113 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/freechessclub/icsgo/chess"
+	"github.com/pkg/errors"
+)
+
+var (
+	moveListRE        *regexp.Regexp
+	moveListPlayersRE *regexp.Regexp
+	moveListMatchRE   *regexp.Regexp
+	moveListMoveRE    *regexp.Regexp
+	moveListResultRE  *regexp.Regexp
+)
+
+func init() {
+	// Movelist for game 12:
+	moveListRE = regexp.MustCompile(`(?m)^\s*Movelist for game ([^:\s]+):`)
+
+	// GuestABCD (UNR) vs. GuestEFGH (1500) --- Sat Jan 14, 03:12 PST 2023
+	moveListPlayersRE = regexp.MustCompile(`^\s*([a-zA-Z]+) \(([^\)]*)\) vs\. ([a-zA-Z]+) \(([^\)]*)\) --- (.*)$`)
+
+	// Unrated blitz match, initial time: 5 minutes, increment: 0 seconds.
+	moveListMatchRE = regexp.MustCompile(`^\s*(Rated|Unrated) (\S+) match, initial time: ([0-9]+) minutes?, increment: ([0-9]+) seconds?\.`)
+
+	//   1.  e4      (0:00)          e5      (0:00.123)
+	moveListMoveRE = regexp.MustCompile(`^\s*([0-9]+)\.\s+(\S+)\s+\(([0-9]+(?::[0-9]+)+)(?:\.([0-9]+))?\)(?:\s+(\S+)\s+\(([0-9]+(?::[0-9]+)+)(?:\.([0-9]+))?\))?`)
+
+	//       {Still in progress} *
+	moveListResultRE = regexp.MustCompile(`^\s*\{([^\}]*)\}\s*(1-0|0-1|1/2-1/2|\*)`)
+}
+
+// ParseMoveList parses the output of the moves and smoves commands into a move
+// list. The starting position of the game is taken from the style12 board
+// sent with the movelist when the startpos ivariable is set
+func ParseMoveList(b []byte) (*MoveList, error) {
+	loc := moveListRE.FindSubmatchIndex(b)
+	if loc == nil {
+		return nil, errors.New("no movelist found")
+	}
+
+	ml := &MoveList{}
+	if id, err := strconv.ParseUint(string(b[loc[2]:loc[3]]), 10, 32); err == nil {
+		ml.GameId = uint32(id)
+	}
+
+	// with the startpos ivariable, the server sends the position the game
+	// started from along with the movelist
+	if m := gameMoveRE.FindSubmatch(b); m != nil && len(m) > 33 {
+		if fen := decodeGameMove(m).Fen; fen != chess.StartFEN {
+			ml.Fen = fen
+		}
+	}
+
+	for _, line := range bytes.Split(b[loc[1]:], []byte("\n")) {
+		if m := moveListPlayersRE.FindSubmatch(line); m != nil {
+			ml.WhiteName = string(m[1])
+			ml.WhiteRating = string(m[2])
+			ml.BlackName = string(m[3])
+			ml.BlackRating = string(m[4])
+			ml.Date = strings.TrimSpace(string(m[5]))
+			continue
+		}
+
+		if m := moveListMatchRE.FindSubmatch(line); m != nil {
+			ml.Rated = string(m[1]) == "Rated"
+			ml.Type = string(m[2])
+			ml.Time = unsafeAtoi(m[3])
+			ml.Inc = unsafeAtoi(m[4])
+			continue
+		}
+
+		if m := moveListMoveRE.FindSubmatch(line); m != nil {
+			ml.Moves = append(ml.Moves, &MoveList_Move{
+				San:  string(m[2]),
+				Time: parseMoveTime(m[3], m[4]),
+			})
+			if len(m[5]) > 0 {
+				ml.Moves = append(ml.Moves, &MoveList_Move{
+					San:  string(m[5]),
+					Time: parseMoveTime(m[6], m[7]),
+				})
+			}
+			continue
+		}
+
+		if m := moveListResultRE.FindSubmatch(line); m != nil {
+			ml.Termination = string(m[1])
+			ml.Result = string(m[2])
+			break
+		}
+	}
+
+	if ml.WhiteName == "" || ml.BlackName == "" {
+		return nil, errors.New("movelist has no players")
+	}
+
+	if ml.Result == "" {
+		ml.Result = "*"
+	}
+
+	return ml, nil
+}
@@ -0,0 +1,84 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"strings"
+	"testing"
+)
+
+// output of moves 93 for a Fischer random game, with the startpos ivariable set
+const wildMoveList = `Movelist for game 93:
+
+GuestQWER (UNR) vs. GuestASDF (UNR) --- Sun Oct 11, 12:00 EDT 2026
+Unrated wild/fr match, initial time: 3 minutes, increment: 0 seconds.
+
+<12> bbqnnrkr pppppppp -------- -------- -------- -------- PPPPPPPP BBQNNRKR W -1 1 1 1 1 0 93 GuestQWER GuestASDF -4 3 0 39 39 180 180 1 none (0:00) none 0 0 0
+
+Move  GuestQWER               GuestASDF
+----  ---------------------   ---------------------
+  1.  e4      (0:00.000)      e5      (0:00.000)
+  2.  Nc3     (0:02.512)      Nc6     (0:01.203)
+      {Still in progress} *
+`
+
+func TestParseMoveListStartPosition(t *testing.T) {
+	ml, err := ParseMoveList([]byte(wildMoveList))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const fen = "bbqnnrkr/pppppppp/8/8/8/8/PPPPPPPP/BBQNNRKR w KQkq - 0 1"
+	if ml.Fen != fen {
+		t.Errorf("got FEN %q, want %q", ml.Fen, fen)
+	}
+	if ml.Type != "wild/fr" || len(ml.Moves) != 4 || ml.Moves[3].San != "Nc6" {
+		t.Errorf("got type %q and moves %v", ml.Type, ml.Moves)
+	}
+
+	pgn := ml.ToPGN()
+	for _, want := range []string{"[SetUp \"1\"]\n", "[FEN \"" + fen + "\"]\n", "1. e4 {[%clk 0:03:00]} e5"} {
+		if !strings.Contains(pgn, want) {
+			t.Errorf("PGN does not contain %q:\n%s", want, pgn)
+		}
+	}
+}
+
+func TestParseMoveListStandardPosition(t *testing.T) {
+	ml, err := ParseMoveList([]byte(standardMoveList))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ml.Fen != "" {
+		t.Errorf("got FEN %q for a game from the standard position", ml.Fen)
+	}
+	if strings.Contains(ml.ToPGN(), "[FEN ") {
+		t.Error("PGN of a game from the standard position has a FEN tag")
+	}
+}
+
+const standardMoveList = `Movelist for game 12:
+
+GuestABCD (UNR) vs. GuestEFGH (1500) --- Sat Jan 14, 03:12 PST 2023
+Unrated blitz match, initial time: 5 minutes, increment: 0 seconds.
+
+Move  GuestABCD               GuestEFGH
+----  ---------------------   ---------------------
+  1.  e4      (0:00)          e5      (0:00)
+      {Still in progress} *
+`
+
+func TestToPGNBlackToMove(t *testing.T) {
+	ml := &MoveList{
+		WhiteName: "GuestABCD",
+		BlackName: "GuestEFGH",
+		Fen:       "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+		Moves:     []*MoveList_Move{{San: "e5"}, {San: "Nf3"}, {San: "Nc6"}},
+	}
+	if pgn := ml.ToPGN(); !strings.Contains(pgn, "\n1... e5 2. Nf3 Nc6 *\n") {
+		t.Errorf("moves are numbered from the wrong side:\n%s", pgn)
+	}
+}
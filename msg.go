@@ -7,17 +7,24 @@ package icsgo
 
 import (
 	"bytes"
+	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/freechessclub/icsgo/chess"
 )
 
 var (
+	infoLineRE  *regexp.Regexp
 	gameMoveRE  *regexp.Regexp
 	gameStartRE *regexp.Regexp
 	gameEndRE   *regexp.Regexp
+	gameAnnRE   *regexp.Regexp
+	unobserveRE *regexp.Regexp
 	chTellRE    *regexp.Regexp
 	pTellRE     *regexp.Regexp
+	partnerRE   *regexp.Regexp
 	kibitzRE    *regexp.Regexp
 	toldMsgRE   *regexp.Regexp
 )
@@ -32,17 +39,108 @@ const (
 	Draw
 	Adjourn
 	Abort
+	Stalemate
+	Repetition
+	FiftyMoveRule
+	GameLength
+	InsufficientMaterial
+	TimeForfeitInsufficientMaterial
+	BothTimeForfeit
+	Adjudication
+	LostConnection
+	CourtesyAbort
+	CourtesyAdjourn
+	FirstMoveAbort
+	Shutdown
+	VariantRule
+	PartnerGame
+)
+
+// outcome of a game
+const (
+	OutcomeUnknown = iota
+	OutcomeWin
+	OutcomeDraw
+	OutcomeAbort
+	OutcomeAdjourn
+)
+
+// relation of the client to a game, as reported in style12
+const (
+	RoleInitialPosition   = -4
+	RoleIsolatedPosition  = -3
+	RoleObservingExamined = -2
+	RoleOpponentMove      = -1
+	RoleObserving         = 0
+	RoleMyMove            = 1
+	RoleExamining         = 2
 )
 
+// gameTermination describes one of the ways in which the server reports the
+// end of a game
+type gameTermination struct {
+	re      *regexp.Regexp
+	reason  uint32
+	outcome uint32
+	// whether the handle captured by re is the winner rather than the loser
+	winner bool
+}
+
+// game terminations, as reported by the server between the braces of a game end message
+var gameTerminations = []gameTermination{
+	{regexp.MustCompile(`^(\w+) resigns$`), Resign, OutcomeWin, false},
+	{regexp.MustCompile(`^(\w+) forfeits by disconnection$`), Disconnect, OutcomeWin, false},
+	{regexp.MustCompile(`^(\w+) checkmated$`), Checkmate, OutcomeWin, false},
+	{regexp.MustCompile(`^(\w+) forfeits on time$`), TimeForfeit, OutcomeWin, false},
+	{regexp.MustCompile(`^(\w+) wins by adjudication$`), Adjudication, OutcomeWin, true},
+	{regexp.MustCompile(`^(\w+) wins by (?:losing all material|having less material \(stalemate\))$`), VariantRule, OutcomeWin, true},
+	{regexp.MustCompile(`^(\w+)'s partner won$`), PartnerGame, OutcomeWin, true},
+	{regexp.MustCompile(`^(\w+)'s partner checkmated$`), PartnerGame, OutcomeWin, false},
+	{regexp.MustCompile(`^Game drawn by mutual agreement$`), Draw, OutcomeDraw, false},
+	{regexp.MustCompile(`^Game drawn because both players ran out of time$`), BothTimeForfeit, OutcomeDraw, false},
+	{regexp.MustCompile(`^Game drawn by repetition$`), Repetition, OutcomeDraw, false},
+	{regexp.MustCompile(`^Game drawn by the 50 move rule$`), FiftyMoveRule, OutcomeDraw, false},
+	{regexp.MustCompile(`^Game drawn due to length$`), GameLength, OutcomeDraw, false},
+	{regexp.MustCompile(`^Game drawn by adjudication$`), Adjudication, OutcomeDraw, false},
+	{regexp.MustCompile(`^Game drawn by stalemate$`), Stalemate, OutcomeDraw, false},
+	{regexp.MustCompile(`^Game was drawn$`), Draw, OutcomeDraw, false},
+	{regexp.MustCompile(`^Partners' game drawn$`), PartnerGame, OutcomeDraw, false},
+	{regexp.MustCompile(`^Neither player has mating material$`), InsufficientMaterial, OutcomeDraw, false},
+	{regexp.MustCompile(`^(\w+) ran out of time and \w+ has no material to mate$`), TimeForfeitInsufficientMaterial, OutcomeDraw, false},
+	{regexp.MustCompile(`^Game aborted on move 1$`), FirstMoveAbort, OutcomeAbort, false},
+	{regexp.MustCompile(`^Game aborted by mutual agreement$`), Abort, OutcomeAbort, false},
+	{regexp.MustCompile(`^Game courtesyaborted by (\w+)$`), CourtesyAbort, OutcomeAbort, false},
+	{regexp.MustCompile(`^Game aborted by adjudication$`), Adjudication, OutcomeAbort, false},
+	{regexp.MustCompile(`^(\w+) lost (?:connection|contact)(?: or quit)?(?: and too few moves)?; game aborted$`), LostConnection, OutcomeAbort, false},
+	{regexp.MustCompile(`^Game aborted by (?:server )?shutdown$`), Shutdown, OutcomeAbort, false},
+	{regexp.MustCompile(`^Game adjourned by mutual agreement$`), Adjourn, OutcomeAdjourn, false},
+	{regexp.MustCompile(`^Game courtesyadjourned by (\w+)$`), CourtesyAdjourn, OutcomeAdjourn, false},
+	{regexp.MustCompile(`^(\w+) lost (?:connection|contact)(?: or quit)?; game adjourned$`), LostConnection, OutcomeAdjourn, false},
+	{regexp.MustCompile(`^Game adjourned by adjudication$`), Adjudication, OutcomeAdjourn, false},
+	{regexp.MustCompile(`^Game adjourned by (?:server )?shutdown$`), Shutdown, OutcomeAdjourn, false},
+}
+
 func init() {
+	// a line of interface information, such as style12 or seekinfo
+	infoLineRE = regexp.MustCompile(`(?m)^<[a-z0-9]+>`)
+
 	// game move
 	// <12> rnbqkb-r pppppppp -----n-- -------- ----P--- -------- PPPPKPPP RNBQ-BNR B -1 0 0 1 1 0 7 Newton Einstein 1 2 12 39 39 119 122 2 K/e1-e2 (0:06) Ke2 0
-	gameMoveRE = regexp.MustCompile(`<12>\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([BW\-])\s(?:\-?[0-7])\s(?:[01])\s(?:[01])\s(?:[01])\s(?:[01])\s(?:[0-9]+)\s([0-9]+)\s([a-zA-Z]+)\s([a-zA-Z]+)\s(\-?[0-3])\s([0-9]+)\s([0-9]+)\s(?:[0-9]+)\s(?:[0-9]+)\s(\-?[0-9]+)\s(\-?[0-9]+)\s([0-9]+)\s(?:\S+)\s\((?:[0-9]+)\:(?:[0-9]+)\)\s(\S+)\s(?:[01])\s(?:[0-9]+)\s(?:[0-9]+)\s*`)
+	gameMoveRE = regexp.MustCompile(`<12>\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([rnbqkpRNBQKP\-]{8})\s([BW\-])\s(\-?[0-7])\s([01])\s([01])\s([01])\s([01])\s([0-9]+)\s([0-9]+)\s([a-zA-Z]+)\s([a-zA-Z]+)\s(\-?[0-4])\s([0-9]+)\s([0-9]+)\s([0-9]+)\s([0-9]+)\s(\-?[0-9]+)\s(\-?[0-9]+)\s([0-9]+)\s(\S+)\s\(([0-9]+(?:\:[0-9]+)+)(?:\.([0-9]+))?\)\s(\S+)\s([01])(?:\s([01])\s([0-9]+))?\s*`)
 
 	// {Game 117 (GuestMDPS vs. guestl) Creating unrated blitz match.}
 	gameStartRE = regexp.MustCompile(`(?s)^\s*\{Game\s([0-9]+)\s\(([a-zA-Z]+)\svs\.\s([a-zA-Z]+)\)\sCreating.*\}.*`)
 
-	gameEndRE = regexp.MustCompile(`(?s)^[^\(\):]*(?:Game\s[0-9]+:.*)?\{Game\s([0-9]+)\s\(([a-zA-Z]+)\svs\.\s([a-zA-Z]+)\)\s([a-zA-Z]+)\s([a-zA-Z0-9\s]+)\}\s(?:[012/]+-[012/]+)?.*`)
+	// {Game 117 (GuestMDPS vs. guestl) GuestMDPS resigns} 0-1
+	gameEndRE = regexp.MustCompile(`(?s)^[^\(\):]*(?:Game\s[0-9]+:.*)?\{Game\s([0-9]+)\s\(([a-zA-Z]+)\svs\.\s([a-zA-Z]+)\)\s([^\}]+)\}\s*(1-0|0-1|1/2-1/2|\*).*`)
+
+	// announcement that may precede the end of a game
+	// Game 117: GuestMDPS resigns the game.
+	gameAnnRE = regexp.MustCompile(`^Game\s[0-9]+:`)
+
+	// Removing game 117 from observation list.
+	// You are no longer examining game 117.
+	unobserveRE = regexp.MustCompile(`(?m)^(?:Removing game ([0-9]+) from observation list|You are no longer examining game ([0-9]+))\.`)
 
 	// channel tell
 	chTellRE = regexp.MustCompile(`(?s)^([a-zA-Z]+)(?:\([A-Z\*]+\))*\(([0-9]+)\):\s+(.*)`)
@@ -50,6 +148,9 @@ func init() {
 	// private tell
 	pTellRE = regexp.MustCompile(`(?s)^([a-zA-Z]+)(?:[\(\[][A-Z0-9\*\-]+[\)\]])* (?:tells you|says):\s+(.*)`)
 
+	// partner tell
+	partnerRE = regexp.MustCompile(`(?s)^([a-zA-Z]+)(?:[\(\[][A-Z0-9\*\-]+[\)\]])* \(your partner\) tells you:\s+(.*)`)
+
 	// kibitz/whispers
 	kibitzRE = regexp.MustCompile(`(?s)^([a-zA-Z]+)(?:\([A-Z0-9\*\-]+\))*\[([0-9]+)\] (?:kibitzes|whispers):\s+(.*)`)
 
@@ -78,55 +179,200 @@ func style12ToFEN(b []byte) string {
 	return fen
 }
 
+// gameMoveFEN completes the piece placement of a style12 board with the
+// side to move, castling availability, en passant square, halfmove clock
+// and fullmove number. Style12 always lists the ranks from White's 8th rank
+// to White's 1st rank, whatever the relation of the client to the game, so
+// the placement never needs to be turned around; the flip field only hints
+// at how the board should be displayed
+func gameMoveFEN(placement string, m *GameMove) string {
+	turn := "w"
+	if m.Turn == "B" {
+		turn = "b"
+	}
+
+	castling := ""
+	if m.WhiteCastleShort {
+		castling += "K"
+	}
+	if m.WhiteCastleLong {
+		castling += "Q"
+	}
+	if m.BlackCastleShort {
+		castling += "k"
+	}
+	if m.BlackCastleLong {
+		castling += "q"
+	}
+	if castling == "" {
+		castling = "-"
+	}
+
+	ep := "-"
+	if m.DoublePawnPush >= 0 && m.DoublePawnPush <= 7 {
+		ep = string(rune('a' + m.DoublePawnPush))
+		if turn == "b" {
+			ep += "3"
+		} else {
+			ep += "6"
+		}
+	}
+
+	moveNo := m.MoveNo
+	if moveNo == 0 {
+		moveNo = 1
+	}
+
+	return fmt.Sprintf("%s %s %s %s %d %d", placement, turn, castling, ep, m.HalfmoveClock, moveNo)
+}
+
 func unsafeAtoi(b []byte) uint32 {
 	i, _ := strconv.Atoi(string(b))
 	return uint32(i)
 }
 
-func getGameResult(p1, p2, who, action string) (string, string, uint32) {
-	action = strings.TrimSpace(action)
-	switch action {
-	case "resigns":
-		if p1 == who {
-			return p2, p1, Resign
-		} else if p2 == who {
-			return p1, p2, Resign
-		}
-	case "forfeits by disconnection":
-		if p1 == who {
-			return p2, p1, Disconnect
-		} else if p2 == who {
-			return p1, p2, Disconnect
-		}
-	case "checkmated":
-		if p1 == who {
-			return p2, p1, Checkmate
-		} else if p2 == who {
-			return p1, p2, Checkmate
-		}
-	case "forfeits on time":
-		if p1 == who {
-			return p2, p1, TimeForfeit
-		} else if p2 == who {
-			return p1, p2, TimeForfeit
-		}
-	case "aborted on move 1":
-	case "aborted by mutual agreement":
-		return p1, p2, Abort
-	case "drawn by mutual agreement":
-	case "drawn because both players ran out of time":
-	case "drawn by repetition":
-	case "drawn by the 50 move rule":
-	case "drawn due to length":
-	case "was drawn":
-	case "player has mating material":
-	case "drawn by adjudication":
-	case "drawn by stalemate":
-		return p1, p2, Draw
-	case "adjourned by mutual agreement":
-		return p1, p2, Adjourn
-	}
-	return p1, p2, Unknown
+// atoi32 parses a signed number, such as the clock of a player who ran out of time
+func atoi32(b []byte) int32 {
+	i, _ := strconv.ParseInt(string(b), 10, 32)
+	return int32(i)
+}
+
+// parseMoveTime parses the time taken for a move, in the form m:ss or h:mm:ss
+// with an optional fraction of milliseconds, into milliseconds
+func parseMoveTime(clock, fraction []byte) uint32 {
+	var t uint32
+	for _, f := range bytes.Split(clock, []byte(":")) {
+		t = t*60 + unsafeAtoi(f)
+	}
+	t *= 1000
+	if len(fraction) > 0 {
+		ms := string(fraction)
+		for len(ms) < 3 {
+			ms += "0"
+		}
+		t += unsafeAtoi([]byte(ms[:3]))
+	}
+	return t
+}
+
+func decodeGameMove(matches [][]byte) *GameMove {
+	placement := ""
+	for i := 1; i < 8; i++ {
+		placement += style12ToFEN(matches[i][:])
+		placement += "/"
+	}
+	placement += style12ToFEN(matches[8][:])
+	r, _ := strconv.Atoi(string(matches[19][:]))
+	role := int32(r)
+	dpp, _ := strconv.Atoi(string(matches[10][:]))
+
+	m := &GameMove{
+		Turn:             string(matches[9][:]),
+		DoublePawnPush:   int32(dpp),
+		WhiteCastleShort: matches[11][0] == '1',
+		WhiteCastleLong:  matches[12][0] == '1',
+		BlackCastleShort: matches[13][0] == '1',
+		BlackCastleLong:  matches[14][0] == '1',
+		HalfmoveClock:    unsafeAtoi(matches[15][:]),
+		GameId:           unsafeAtoi(matches[16][:]),
+		WhiteName:        string(matches[17][:]),
+		BlackName:        string(matches[18][:]),
+		Role:             role,
+		Time:             unsafeAtoi(matches[20][:]),
+		Inc:              unsafeAtoi(matches[21][:]),
+		WhiteStrength:    unsafeAtoi(matches[22][:]),
+		BlackStrength:    unsafeAtoi(matches[23][:]),
+		WhiteTime:        atoi32(matches[24][:]),
+		BlackTime:        atoi32(matches[25][:]),
+		MoveNo:           unsafeAtoi(matches[26][:]),
+		VerboseMove:      string(matches[27][:]),
+		MoveTime:         parseMoveTime(matches[28], matches[29]),
+		Ms:               len(matches[29]) > 0,
+		Move:             string(matches[30][:]),
+		Drop:             bytes.IndexByte(matches[30], '@') != -1,
+		Flip:             matches[31][0] == '1',
+		ClockTicking:     len(matches[32]) > 0 && matches[32][0] == '1',
+		Lag:              unsafeAtoi(matches[33][:]),
+	}
+	m.Fen = gameMoveFEN(placement, m)
+	return m
+}
+
+// Position returns the position after the move, which can be used to
+// validate moves locally before sending them to the server
+func (m *GameMove) Position() (*chess.Position, error) {
+	return chess.ParseFEN(m.Fen)
+}
+
+// getGameResult classifies the end of a game between white (p1) and black (p2)
+// given the termination reported by the server and the PGN result, returning
+// the winner and loser (empty unless the game was decisive), the reason and
+// the outcome of the game
+func getGameResult(p1, p2, termination, result string) (string, string, uint32, uint32) {
+	termination = strings.TrimSpace(termination)
+
+	var reason, outcome uint32 = Unknown, OutcomeUnknown
+	var who string
+	var winner bool
+	for _, t := range gameTerminations {
+		m := t.re.FindStringSubmatch(termination)
+		if m == nil {
+			continue
+		}
+		reason, outcome, winner = t.reason, t.outcome, t.winner
+		if len(m) > 1 {
+			who = m[1]
+		}
+		break
+	}
+
+	// the result is authoritative on who won the game
+	switch result {
+	case "1-0":
+		return p1, p2, reason, OutcomeWin
+	case "0-1":
+		return p2, p1, reason, OutcomeWin
+	case "1/2-1/2":
+		return "", "", reason, OutcomeDraw
+	}
+
+	if outcome == OutcomeWin {
+		if who != p1 && who != p2 {
+			return "", "", reason, OutcomeUnknown
+		}
+		if (who == p1) != winner {
+			return p2, p1, reason, outcome
+		}
+		return p1, p2, reason, outcome
+	}
+	return "", "", reason, outcome
+}
+
+// decode decodes server output into messages, taking into account the state
+// of the session, such as block mode and games that are about to start
+func (client *Client) decode(msg []byte) []interface{} {
+	var msgs []interface{}
+	if client.hasFeature("block") {
+		msgs = client.blocks.decode(msg)
+	} else {
+		msgs = decodeMessages(msg)
+	}
+
+	// without the negotiated ivariables, the unit of the clocks can only be
+	// guessed from the move time
+	if client.hasFeature("lock") {
+		ms := client.hasFeature("ms")
+		for _, m := range msgs {
+			if m, ok := m.(*GameMove); ok {
+				m.Ms = ms
+			}
+		}
+	}
+
+	client.compressed.expand(msgs)
+	client.games.merge(msgs)
+	client.holdings.merge(msgs)
+	return msgs
 }
 
 func decodeMessages(msg []byte) []interface{} {
@@ -139,44 +385,91 @@ func decodeMessages(msg []byte) []interface{} {
 		return nil
 	}
 
-	matches := gameMoveRE.FindSubmatch(msg)
-	if matches != nil && len(matches) >= 18 {
+	// lines of interface information are interleaved with other server
+	// output, so they are decoded on their own, while the lines between
+	// them, such as a tell that wraps over several lines, are kept together
+	if infoLineRE.Match(msg) {
 		m := bytes.Split(msg, []byte("\n"))
 		if len(m) > 1 {
 			var msgs []interface{}
+			var text [][]byte
+			flush := func() {
+				if len(text) > 0 {
+					msgs = append(msgs, decodeMessages(bytes.Join(text, []byte("\n")))...)
+					text = nil
+				}
+			}
 			for i := 0; i < len(m); i++ {
-				if len(m[i]) > 0 {
+				switch {
+				case moveListRE.Match(m[i]):
+					// keep a movelist in one piece up to its result line
+					flush()
+					j := i
+					for j < len(m)-1 && !moveListResultRE.Match(m[j]) {
+						j++
+					}
+					// the movelist may hold a board, so it is not decoded again
+					ml := bytes.Join(m[i:j+1], []byte("\n"))
+					if parsed, err := ParseMoveList(ml); err == nil {
+						msgs = append(msgs, parsed)
+					} else {
+						msgs = append(msgs, &Message{Message: string(ml)})
+					}
+					i = j
+				case infoLineRE.Match(m[i]):
+					flush()
 					msgs = append(msgs, decodeMessages(m[i])...)
+				case len(bytes.TrimSpace(m[i])) == 0:
+					// blank lines separate messages
+					flush()
+				default:
+					// the start and end of a game are messages of their own,
+					// although the end may follow its announcement
+					if gameStartRE.Match(m[i]) ||
+						(gameEndRE.Match(m[i]) && !(len(text) == 1 && gameAnnRE.Match(text[0]))) {
+						flush()
+					}
+					text = append(text, m[i])
 				}
 			}
+			flush()
 			return msgs
 		}
+	}
 
-		fen := ""
-		for i := 1; i < 8; i++ {
-			fen += style12ToFEN(matches[i][:])
-			fen += "/"
+	if moveListRE.Match(msg) {
+		if ml, err := ParseMoveList(msg); err == nil {
+			return []interface{}{ml}
 		}
-		fen += style12ToFEN(matches[8][:])
-		r, _ := strconv.Atoi(string(matches[13][:]))
-		role := int32(r)
+	}
 
-		return []interface{}{
-			&GameMove{
-				Fen:       fen,
-				Turn:      string(matches[9][:]),
-				GameId:    unsafeAtoi(matches[10][:]),
-				WhiteName: string(matches[11][:]),
-				BlackName: string(matches[12][:]),
-				Role:      role,
-				Time:      unsafeAtoi(matches[14][:]),
-				Inc:       unsafeAtoi(matches[15][:]),
-				WhiteTime: unsafeAtoi(matches[16][:]),
-				BlackTime: unsafeAtoi(matches[17][:]),
-				MoveNo:    unsafeAtoi(matches[18][:]),
-				Move:      string(matches[19][:]),
-			},
-		}
+	matches := gameMoveRE.FindSubmatch(msg)
+	if matches != nil && len(matches) > 33 {
+		return []interface{}{decodeGameMove(matches)}
+	}
+
+	if m := decodeCompressedMove(msg); m != nil {
+		return []interface{}{m}
+	}
+
+	if m := decodeSeekMessage(msg); m != nil {
+		return []interface{}{m}
+	}
+
+	if m := decodeOfferMessage(msg); m != nil {
+		return []interface{}{m}
+	}
+
+	if m := decodePinMessage(msg); m != nil {
+		return []interface{}{m}
+	}
+
+	if m := decodeGameInfo(msg); m != nil {
+		return []interface{}{m}
+	}
+
+	if m := decodeHoldings(msg); m != nil {
+		return []interface{}{m}
 	}
 
 	matches = gameStartRE.FindSubmatch(msg)
@@ -191,20 +484,32 @@ func decodeMessages(msg []byte) []interface{} {
 	}
 
 	matches = gameEndRE.FindSubmatch(msg)
-	if matches != nil && len(matches) > 4 {
+	if matches != nil && len(matches) > 5 {
 		p1 := string(matches[2][:])
 		p2 := string(matches[3][:])
-		who := string(matches[4][:])
-		action := string(matches[5][:])
+		termination := strings.TrimSpace(string(matches[4][:]))
+		result := string(matches[5][:])
 
-		winner, loser, reason := getGameResult(p1, p2, who, action)
+		winner, loser, reason, outcome := getGameResult(p1, p2, termination, result)
 		return []interface{}{
 			&GameEnd{
-				GameId:  unsafeAtoi(matches[1][:]),
-				Winner:  winner,
-				Loser:   loser,
-				Reason:  reason,
-				Message: string(msg),
+				GameId:      unsafeAtoi(matches[1][:]),
+				Winner:      winner,
+				Loser:       loser,
+				Reason:      reason,
+				Message:     string(msg),
+				Result:      result,
+				Outcome:     outcome,
+				Termination: termination,
+			},
+		}
+	}
+
+	matches = unobserveRE.FindSubmatch(msg)
+	if matches != nil && len(matches) > 2 {
+		return []interface{}{
+			&GameUnobserved{
+				GameId: unsafeAtoi(append(matches[1], matches[2]...)),
 			},
 		}
 	}
@@ -220,6 +525,16 @@ func decodeMessages(msg []byte) []interface{} {
 		}
 	}
 
+	matches = partnerRE.FindSubmatch(msg)
+	if matches != nil && len(matches) > 2 {
+		return []interface{}{
+			&PartnerTell{
+				User:    string(matches[1][:]),
+				Message: string(bytes.Replace(matches[2][:], []byte("\n"), []byte{}, -1)),
+			},
+		}
+	}
+
 	matches = pTellRE.FindSubmatch(msg)
 	if matches != nil && len(matches) > 2 {
 		return []interface{}{
@@ -0,0 +1,194 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// decodeOne decodes a line of server output that holds a single message
+func decodeOne(t *testing.T, line string) interface{} {
+	msgs := decodeMessages([]byte(line))
+	if len(msgs) != 1 {
+		t.Fatalf("%s: decoded %d messages, want 1", line, len(msgs))
+	}
+	return msgs[0]
+}
+
+func TestDecodeStyle12(t *testing.T) {
+	tests := []struct {
+		line string
+		want *GameMove
+	}{
+		{
+			// the example of help style12
+			"<12> rnbqkb-r pppppppp -----n-- -------- ----P--- -------- PPPPKPPP RNBQ-BNR B -1 0 0 1 1 0 7 Newton Einstein 1 2 12 39 39 119 122 2 K/e1-e2 (0:06) Ke2 0",
+			&GameMove{
+				Turn: "B", DoublePawnPush: -1, BlackCastleShort: true, BlackCastleLong: true,
+				GameId: 7, WhiteName: "Newton", BlackName: "Einstein", Role: RoleMyMove,
+				Time: 2, Inc: 12, WhiteStrength: 39, BlackStrength: 39, WhiteTime: 119, BlackTime: 122,
+				MoveNo: 2, VerboseMove: "K/e1-e2", MoveTime: 6000, Move: "Ke2",
+			},
+		},
+		{
+			// white ran out of time, with the ticking and lag fields
+			"<12> -------k -------- -------- -------- -------- -------- -------- K------Q W -1 0 0 0 0 12 57 GuestABCD GuestEFGH -1 1 0 9 0 -5 41 61 Q/a2-h1 (0:03) Qh1 1 1 250",
+			&GameMove{
+				Turn: "W", DoublePawnPush: -1, HalfmoveClock: 12,
+				GameId: 57, WhiteName: "GuestABCD", BlackName: "GuestEFGH", Role: RoleOpponentMove,
+				Time: 1, WhiteStrength: 9, WhiteTime: -5, BlackTime: 41,
+				MoveNo: 61, VerboseMove: "Q/a2-h1", MoveTime: 3000, Move: "Qh1",
+				Flip: true, ClockTicking: true, Lag: 250,
+			},
+		},
+		{
+			// observed game with the ms ivariable, both clocks negative
+			"<12> rnbqkbnr pppp-ppp -------- ----p--- ----P--- -------- PPPP-PPP RNBQKBNR W 4 1 1 1 1 0 12 Alice Bob 0 0 0 39 39 -1200 -350 2 P/e7-e5 (0:01.234) e5 0 1 0",
+			&GameMove{
+				Turn: "W", DoublePawnPush: 4, WhiteCastleShort: true, WhiteCastleLong: true,
+				BlackCastleShort: true, BlackCastleLong: true,
+				GameId: 12, WhiteName: "Alice", BlackName: "Bob", Role: RoleObserving,
+				WhiteStrength: 39, BlackStrength: 39, WhiteTime: -1200, BlackTime: -350,
+				MoveNo: 2, VerboseMove: "P/e7-e5", MoveTime: 1234, Ms: true, Move: "e5", ClockTicking: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		m, ok := decodeOne(t, tt.line).(*GameMove)
+		if !ok {
+			t.Fatalf("%s: not decoded as a GameMove", tt.line)
+		}
+		m.Fen = ""
+		if !proto.Equal(m, tt.want) {
+			t.Errorf("%s:\ngot  %v\nwant %v", tt.line, m, tt.want)
+		}
+	}
+}
+
+func TestGameMoveFEN(t *testing.T) {
+	tests := []struct {
+		line string
+		fen  string
+	}{
+		{
+			"<12> rnbqkbnr pppppppp -------- -------- -------- -------- PPPPPPPP RNBQKBNR W -1 1 1 1 1 0 3 Alice Bob 1 3 0 39 39 180 180 1 none (0:00) none 0 0 0",
+			"rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1",
+		},
+		{
+			"<12> rnbqkbnr pppppppp -------- -------- ----P--- -------- PPPP-PPP RNBQKBNR B 4 1 1 1 1 0 3 Alice Bob -1 3 0 39 39 180 180 1 P/e2-e4 (0:00) e4 0 1 0",
+			"rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+		},
+		{
+			"<12> rnbqkbnr pppp-ppp -------- ----p--- ----P--- -------- PPPP-PPP RNBQKBNR W 4 1 1 1 1 0 3 Alice Bob 1 3 0 39 39 178 179 2 P/e7-e5 (0:01) e5 0 1 0",
+			"rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2",
+		},
+		{
+			"<12> rnbqkb-r pppppppp -----n-- -------- ----P--- -------- PPPPKPPP RNBQ-BNR B -1 0 0 1 1 0 7 Newton Einstein 1 2 12 39 39 119 122 2 K/e1-e2 (0:06) Ke2 0",
+			"rnbqkb1r/pppppppp/5n2/8/4P3/8/PPPPKPPP/RNBQ1BNR b kq - 0 2",
+		},
+		{
+			"<12> -------k -------- -------- -------- -------- -------- -------- K------Q W -1 0 0 0 0 12 57 GuestABCD GuestEFGH -1 1 0 9 0 -5 41 61 Q/a2-h1 (0:03) Qh1 1 1 250",
+			"7k/8/8/8/8/8/8/K6Q w - - 12 61",
+		},
+	}
+	for _, tt := range tests {
+		m, ok := decodeOne(t, tt.line).(*GameMove)
+		if !ok {
+			t.Fatalf("%s: not decoded as a GameMove", tt.line)
+		}
+		if m.Fen != tt.fen {
+			t.Errorf("%s: got FEN %q, want %q", tt.line, m.Fen, tt.fen)
+		}
+	}
+}
+
+func TestGetGameResult(t *testing.T) {
+	tests := []struct {
+		termination string
+		result      string
+		winner      string
+		loser       string
+		reason      uint32
+		outcome     uint32
+	}{
+		{"Bob resigns", "1-0", "Alice", "Bob", Resign, OutcomeWin},
+		{"Alice forfeits by disconnection", "0-1", "Bob", "Alice", Disconnect, OutcomeWin},
+		{"Bob checkmated", "1-0", "Alice", "Bob", Checkmate, OutcomeWin},
+		{"Alice forfeits on time", "0-1", "Bob", "Alice", TimeForfeit, OutcomeWin},
+		{"Alice wins by adjudication", "1-0", "Alice", "Bob", Adjudication, OutcomeWin},
+		{"Bob wins by losing all material", "0-1", "Bob", "Alice", VariantRule, OutcomeWin},
+		{"Alice wins by having less material (stalemate)", "1-0", "Alice", "Bob", VariantRule, OutcomeWin},
+		{"Bob's partner won", "0-1", "Bob", "Alice", PartnerGame, OutcomeWin},
+		{"Alice's partner checkmated", "0-1", "Bob", "Alice", PartnerGame, OutcomeWin},
+		{"Partners' game drawn", "1/2-1/2", "", "", PartnerGame, OutcomeDraw},
+		{"Game drawn by mutual agreement", "1/2-1/2", "", "", Draw, OutcomeDraw},
+		{"Game drawn because both players ran out of time", "1/2-1/2", "", "", BothTimeForfeit, OutcomeDraw},
+		{"Game drawn by repetition", "1/2-1/2", "", "", Repetition, OutcomeDraw},
+		{"Game drawn by the 50 move rule", "1/2-1/2", "", "", FiftyMoveRule, OutcomeDraw},
+		{"Game drawn due to length", "1/2-1/2", "", "", GameLength, OutcomeDraw},
+		{"Game drawn by adjudication", "1/2-1/2", "", "", Adjudication, OutcomeDraw},
+		{"Game drawn by stalemate", "1/2-1/2", "", "", Stalemate, OutcomeDraw},
+		{"Game was drawn", "1/2-1/2", "", "", Draw, OutcomeDraw},
+		{"Neither player has mating material", "1/2-1/2", "", "", InsufficientMaterial, OutcomeDraw},
+		{"Bob ran out of time and Alice has no material to mate", "1/2-1/2", "", "", TimeForfeitInsufficientMaterial, OutcomeDraw},
+		{"Game aborted on move 1", "*", "", "", FirstMoveAbort, OutcomeAbort},
+		{"Game aborted by mutual agreement", "*", "", "", Abort, OutcomeAbort},
+		{"Game courtesyaborted by Alice", "*", "", "", CourtesyAbort, OutcomeAbort},
+		{"Game aborted by adjudication", "*", "", "", Adjudication, OutcomeAbort},
+		{"Bob lost connection; game aborted", "*", "", "", LostConnection, OutcomeAbort},
+		{"Bob lost connection and too few moves; game aborted", "*", "", "", LostConnection, OutcomeAbort},
+		{"Alice lost contact or quit; game aborted", "*", "", "", LostConnection, OutcomeAbort},
+		{"Game aborted by shutdown", "*", "", "", Shutdown, OutcomeAbort},
+		{"Game aborted by server shutdown", "*", "", "", Shutdown, OutcomeAbort},
+		{"Game adjourned by mutual agreement", "*", "", "", Adjourn, OutcomeAdjourn},
+		{"Game courtesyadjourned by Bob", "*", "", "", CourtesyAdjourn, OutcomeAdjourn},
+		{"Alice lost connection; game adjourned", "*", "", "", LostConnection, OutcomeAdjourn},
+		{"Bob lost contact or quit; game adjourned", "*", "", "", LostConnection, OutcomeAdjourn},
+		{"Game adjourned by adjudication", "*", "", "", Adjudication, OutcomeAdjourn},
+		{"Game adjourned by shutdown", "*", "", "", Shutdown, OutcomeAdjourn},
+		{"Game adjourned by server shutdown", "*", "", "", Shutdown, OutcomeAdjourn},
+		// a decisive result without a PGN result still names the winner
+		{"Bob resigns", "*", "Alice", "Bob", Resign, OutcomeWin},
+		{"Bob's partner won", "*", "Bob", "Alice", PartnerGame, OutcomeWin},
+		{"Carol resigns", "*", "", "", Resign, OutcomeUnknown},
+		{"Something unexpected happened", "*", "", "", Unknown, OutcomeUnknown},
+	}
+	for _, tt := range tests {
+		winner, loser, reason, outcome := getGameResult("Alice", "Bob", tt.termination, tt.result)
+		if winner != tt.winner || loser != tt.loser || reason != tt.reason || outcome != tt.outcome {
+			t.Errorf("%s %s: got (%q, %q, %d, %d), want (%q, %q, %d, %d)", tt.termination, tt.result,
+				winner, loser, reason, outcome, tt.winner, tt.loser, tt.reason, tt.outcome)
+		}
+	}
+}
+
+func TestDecodePartnerGameEnd(t *testing.T) {
+	m, ok := decodeOne(t, "{Game 42 (Alice vs. Bob) Bob's partner won} 0-1").(*GameEnd)
+	if !ok {
+		t.Fatal("not decoded as a GameEnd")
+	}
+	if m.GameId != 42 || m.Winner != "Bob" || m.Loser != "Alice" || m.Reason != PartnerGame || m.Outcome != OutcomeWin {
+		t.Errorf("got %v", m)
+	}
+}
+
+func TestDecodeGameEndAfterBoard(t *testing.T) {
+	chunk := "<12> -------k -------- -------- -------- -------- -------- -------- K------Q B -1 0 0 0 0 0 42 Alice Bob 0 3 0 9 0 170 175 61 Q/a2-h1 (0:03) Qh1 0 1 0\n" +
+		"Game 42: Bob resigns the game.\n" +
+		"{Game 42 (Alice vs. Bob) Bob resigns} 1-0"
+	msgs := decodeMessages([]byte(chunk))
+	if len(msgs) != 2 {
+		t.Fatalf("decoded %d messages, want 2: %v", len(msgs), msgs)
+	}
+	if _, ok := msgs[0].(*GameMove); !ok {
+		t.Errorf("got %T, want *GameMove", msgs[0])
+	}
+	if m, ok := msgs[1].(*GameEnd); !ok || m.Winner != "Alice" || m.Reason != Resign {
+		t.Errorf("got %v, want the end of game 42", msgs[1])
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+)
+
+var (
+	offerRE        *regexp.Regexp
+	offerRemovedRE *regexp.Regexp
+)
+
+func init() {
+	// <pf> 6 w=visar t=match p=visar (1504) [black] GuestXYZA (----) unrated blitz 2 12
+	// <pt> 7 w=GuestXYZA t=draw p=#
+	offerRE = regexp.MustCompile(`^<(pf|pt)>\s+([0-9]+)\s+w=(\S+)\s+t=(\S+)\s+p=(.*)$`)
+
+	// <pr> 6
+	offerRemovedRE = regexp.MustCompile(`^<pr>\s+([0-9]+)\s*$`)
+}
+
+func decodeOfferMessage(msg []byte) interface{} {
+	if !bytes.HasPrefix(msg, []byte("<p")) {
+		return nil
+	}
+
+	matches := offerRE.FindSubmatch(msg)
+	if matches != nil && len(matches) > 5 {
+		id := unsafeAtoi(matches[2])
+		params := string(bytes.TrimSpace(matches[5]))
+		if string(matches[1]) == "pf" {
+			return &OfferReceived{
+				Id:     id,
+				From:   string(matches[3]),
+				Type:   string(matches[4]),
+				Params: params,
+			}
+		}
+		return &OfferSent{
+			Id:     id,
+			To:     string(matches[3]),
+			Type:   string(matches[4]),
+			Params: params,
+		}
+	}
+
+	matches = offerRemovedRE.FindSubmatch(msg)
+	if matches != nil && len(matches) > 1 {
+		return &OfferRemoved{
+			Id: unsafeAtoi(matches[1]),
+		}
+	}
+
+	return nil
+}
+
+// AcceptOffer accepts the offer with the given identifier
+func (client *Client) AcceptOffer(id uint32) error {
+	return client.Send([]byte(fmt.Sprintf("accept %d", id)))
+}
+
+// DeclineOffer declines the offer with the given identifier
+func (client *Client) DeclineOffer(id uint32) error {
+	return client.Send([]byte(fmt.Sprintf("decline %d", id)))
+}
+
+// WithdrawOffer withdraws an offer we made with the given identifier
+func (client *Client) WithdrawOffer(id uint32) error {
+	return client.Send([]byte(fmt.Sprintf("withdraw %d", id)))
+}
@@ -0,0 +1,56 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeOfferMessages(t *testing.T) {
+	tests := []struct {
+		line string
+		want proto.Message
+	}{
+		{
+			"<pf> 6 w=visar t=match p=visar (1504) [black] GuestXYZA (----) unrated blitz 2 12",
+			&OfferReceived{Id: 6, From: "visar", Type: "match", Params: "visar (1504) [black] GuestXYZA (----) unrated blitz 2 12"},
+		},
+		{
+			"<pf> 12 w=Bob t=takeback p=2",
+			&OfferReceived{Id: 12, From: "Bob", Type: "takeback", Params: "2"},
+		},
+		{
+			"<pf> 3 w=Carol t=partner p=#",
+			&OfferReceived{Id: 3, From: "Carol", Type: "partner", Params: "#"},
+		},
+		{
+			"<pt> 7 w=GuestXYZA t=draw p=#",
+			&OfferSent{Id: 7, To: "GuestXYZA", Type: "draw", Params: "#"},
+		},
+		{
+			"<pt> 8 w=Alice t=match p=GuestXYZA (----) Alice (1720) rated crazyhouse 3 0",
+			&OfferSent{Id: 8, To: "Alice", Type: "match", Params: "GuestXYZA (----) Alice (1720) rated crazyhouse 3 0"},
+		},
+		{"<pr> 6", &OfferRemoved{Id: 6}},
+		{"<pr> 115", &OfferRemoved{Id: 115}},
+	}
+	for _, tt := range tests {
+		got, ok := decodeOne(t, tt.line).(proto.Message)
+		if !ok || !proto.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeMalformedOffer(t *testing.T) {
+	for _, line := range []string{"<pf> w=visar t=match", "<pr>", "<pt> 7 t=draw p=#"} {
+		if m := decodeOfferMessage([]byte(line)); m != nil {
+			t.Errorf("%s: decoded as %v", line, m)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/freechessclub/icsgo/chess"
+)
+
+// the maximum length of a line of PGN movetext
+const pgnLineLength = 79
+
+// types of game that are played with the standard rules of chess
+var standardTypes = map[string]bool{
+	"":          true,
+	"lightning": true,
+	"blitz":     true,
+	"standard":  true,
+	"untimed":   true,
+}
+
+// pgnDate converts a date as reported by the server into a PGN date
+func pgnDate(date string) string {
+	for _, layout := range []string{"Mon Jan _2, 15:04 MST 2006", "Mon Jan 2, 15:04 MST 2006"} {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t.Format("2006.01.02")
+		}
+	}
+	return "????.??.??"
+}
+
+// pgnString escapes a PGN tag value
+func pgnString(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	return strings.Replace(s, `"`, `\"`, -1)
+}
+
+// formatClock formats a clock in milliseconds as h:mm:ss, with tenths of a
+// second if there are any
+func formatClock(ms int64) string {
+	if ms < 0 {
+		ms = 0
+	}
+	s := ms / 1000
+	clk := fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	if tenths := ms % 1000 / 100; tenths > 0 {
+		clk += fmt.Sprintf(".%d", tenths)
+	}
+	return clk
+}
+
+// ToPGN exports the move list as a game in PGN format, annotating each move
+// with the clock of the player who made it
+func (ml *MoveList) ToPGN() string {
+	result := ml.Result
+	if result == "" {
+		result = "*"
+	}
+
+	var b strings.Builder
+	tag := func(name, value string) {
+		fmt.Fprintf(&b, "[%s \"%s\"]\n", name, pgnString(value))
+	}
+
+	rated := "unrated"
+	if ml.Rated {
+		rated = "rated"
+	}
+	tag("Event", strings.TrimSpace(fmt.Sprintf("ICS %s %s game", rated, ml.Type)))
+	tag("Site", "?")
+	tag("Date", pgnDate(ml.Date))
+	tag("Round", "-")
+	tag("White", ml.WhiteName)
+	tag("Black", ml.BlackName)
+	tag("Result", result)
+	if _, err := strconv.Atoi(ml.WhiteRating); err == nil {
+		tag("WhiteElo", ml.WhiteRating)
+	}
+	if _, err := strconv.Atoi(ml.BlackRating); err == nil {
+		tag("BlackElo", ml.BlackRating)
+	}
+	if ml.Time == 0 && ml.Inc == 0 {
+		tag("TimeControl", "-")
+	} else {
+		tag("TimeControl", fmt.Sprintf("%d+%d", ml.Time*60, ml.Inc))
+	}
+	if !standardTypes[ml.Type] {
+		tag("Variant", ml.Type)
+	}
+
+	// number the moves from the starting position
+	first, offset := 1, 0
+	if ml.Fen != "" {
+		tag("SetUp", "1")
+		tag("FEN", ml.Fen)
+		if pos, err := chess.ParseFEN(ml.Fen); err == nil {
+			first = pos.FullmoveNumber
+			if pos.Turn == chess.Black {
+				offset = 1
+			}
+		}
+	}
+	b.WriteString("\n")
+
+	var tokens []string
+	clocks := [2]int64{int64(ml.Time) * 60000, int64(ml.Time) * 60000}
+	for i, m := range ml.Moves {
+		side := (i + offset) % 2
+		if side == 0 {
+			tokens = append(tokens, strconv.Itoa(first+(i+offset)/2)+".")
+		} else if i == 0 {
+			tokens = append(tokens, strconv.Itoa(first)+"...")
+		}
+		tokens = append(tokens, m.San)
+
+		if ml.Time > 0 || ml.Inc > 0 {
+			clocks[side] += int64(ml.Inc)*1000 - int64(m.Time)
+			if m.Clock > 0 {
+				clocks[side] = int64(m.Clock)
+			}
+			tokens = append(tokens, "{[%clk "+formatClock(clocks[side])+"]}")
+		}
+	}
+	if ml.Termination != "" {
+		tokens = append(tokens, "{"+ml.Termination+"}")
+	}
+	tokens = append(tokens, result)
+
+	line := 0
+	for i, t := range tokens {
+		if i > 0 {
+			if line+1+len(t) > pgnLineLength {
+				b.WriteString("\n")
+				line = 0
+			} else {
+				b.WriteString(" ")
+				line++
+			}
+		}
+		b.WriteString(t)
+		line += len(t)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,47 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"bytes"
+	"regexp"
+)
+
+var (
+	userArrivedRE  *regexp.Regexp
+	userDepartedRE *regexp.Regexp
+)
+
+func init() {
+	// <wa> visar, followed by details of the user such as titles and ratings
+	userArrivedRE = regexp.MustCompile(`^<wa>\s+([a-zA-Z]+)(.*)$`)
+
+	// <wd> visar
+	userDepartedRE = regexp.MustCompile(`^<wd>\s+([a-zA-Z]+)\s*$`)
+}
+
+func decodePinMessage(msg []byte) interface{} {
+	if !bytes.HasPrefix(msg, []byte("<w")) {
+		return nil
+	}
+
+	matches := userArrivedRE.FindSubmatch(msg)
+	if matches != nil && len(matches) > 2 {
+		return &UserArrived{
+			Handle: string(matches[1]),
+			Info:   string(bytes.TrimSpace(matches[2])),
+		}
+	}
+
+	matches = userDepartedRE.FindSubmatch(msg)
+	if matches != nil && len(matches) > 1 {
+		return &UserDeparted{
+			Handle: string(matches[1]),
+		}
+	}
+
+	return nil
+}
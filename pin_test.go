@@ -0,0 +1,31 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodePinMessages(t *testing.T) {
+	tests := []struct {
+		line string
+		want proto.Message
+	}{
+		{"<wa> GuestXQJV", &UserArrived{Handle: "GuestXQJV"}},
+		{"<wa> visar{C}1504,2194,0,1650", &UserArrived{Handle: "visar", Info: "{C}1504,2194,0,1650"}},
+		{"<wa> Shirov(GM) 2705", &UserArrived{Handle: "Shirov", Info: "(GM) 2705"}},
+		{"<wd> visar", &UserDeparted{Handle: "visar"}},
+		{"<wd> GuestXQJV ", &UserDeparted{Handle: "GuestXQJV"}},
+	}
+	for _, tt := range tests {
+		got, ok := decodeOne(t, tt.line).(proto.Message)
+		if !ok || !proto.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
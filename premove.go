@@ -0,0 +1,176 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"sync"
+	"time"
+
+	"github.com/freechessclub/icsgo/chess"
+	"github.com/pkg/errors"
+)
+
+// Premover holds moves queued in advance for a game we are playing, and
+// sends them as soon as the opponent has moved
+type Premover struct {
+	mu     sync.Mutex
+	client *Client
+	game   uint32
+	queue  []string
+	// the latest position of the game, and whether a move was already sent in it
+	last      *GameMove
+	received  time.Time
+	moved     bool
+	ended     bool
+	onSent    []func(move string, latency time.Duration)
+	onDiscard []func(moves []string, err error)
+	onError   []func(move string, err error)
+}
+
+// NewPremover creates a premover for the given game. The premover must be fed
+// with the messages received from the server, for instance by registering its
+// Update method with Client.OnEvent
+func NewPremover(client *Client, game uint32) *Premover {
+	return &Premover{
+		client: client,
+		game:   game,
+	}
+}
+
+// OnSent registers a callback invoked when a queued move is sent, with the
+// latency between receiving the opponent's move and sending ours
+func (p *Premover) OnSent(fn func(move string, latency time.Duration)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onSent = append(p.onSent, fn)
+}
+
+// OnDiscard registers a callback invoked with the queued moves that were
+// discarded because the first of them was illegal in the new position
+func (p *Premover) OnDiscard(fn func(moves []string, err error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onDiscard = append(p.onDiscard, fn)
+}
+
+// OnError registers a callback invoked when a queued move could not be sent
+// to the server. The move stays at the front of the queue, and sending it is
+// tried again when the next move is queued or the position is updated
+func (p *Premover) OnError(fn func(move string, err error)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onError = append(p.onError, fn)
+}
+
+// Queue appends moves, in SAN, LAN or UCI notation, to the queue. If it is
+// already our move, the first move is sent right away
+func (p *Premover) Queue(moves ...string) {
+	p.mu.Lock()
+	p.queue = append(p.queue, moves...)
+	p.mu.Unlock()
+	p.play()
+}
+
+// Clear discards all queued moves
+func (p *Premover) Clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.queue = nil
+}
+
+// Pending returns the queued moves
+func (p *Premover) Pending() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string{}, p.queue...)
+}
+
+// Update applies a message received from the server to the premover;
+// messages of other games are ignored
+func (p *Premover) Update(msg interface{}) {
+	switch m := msg.(type) {
+	case *GameMove:
+		if m.GameId != p.game {
+			return
+		}
+		p.mu.Lock()
+		p.last, p.received, p.moved = m, time.Now(), false
+		p.mu.Unlock()
+		p.play()
+	case *GameEnd:
+		if m.GameId != p.game {
+			return
+		}
+		p.mu.Lock()
+		p.ended, p.queue = true, nil
+		p.mu.Unlock()
+	}
+}
+
+// play sends the first queued move if it is our move and the move is legal,
+// or discards the queue if it is not
+func (p *Premover) play() {
+	p.mu.Lock()
+	if p.ended || p.moved || p.last == nil || p.last.Role != RoleMyMove || len(p.queue) == 0 {
+		p.mu.Unlock()
+		return
+	}
+
+	move, err := legalMove(p.last, p.queue[0])
+	if err != nil {
+		discarded := p.queue
+		p.queue = nil
+		callbacks := p.onDiscard
+		p.mu.Unlock()
+		for _, fn := range callbacks {
+			fn(discarded, err)
+		}
+		return
+	}
+
+	queued, last := p.queue[0], p.last
+	p.queue = p.queue[1:]
+	p.moved = true
+	received := p.received
+	callbacks := p.onSent
+	p.mu.Unlock()
+
+	if err := p.client.Send([]byte(move)); err != nil {
+		p.mu.Lock()
+		if !p.ended {
+			p.queue = append([]string{queued}, p.queue...)
+		}
+		if p.last == last {
+			p.moved = false
+		}
+		onError := p.onError
+		p.mu.Unlock()
+		for _, fn := range onError {
+			fn(move, errors.Wrapf(err, "sending premove %s in game %d", move, p.game))
+		}
+		return
+	}
+	latency := time.Since(received)
+	for _, fn := range callbacks {
+		fn(move, latency)
+	}
+}
+
+// legalMove checks a move in SAN, LAN or UCI notation against the position,
+// returning it in SAN
+func legalMove(m *GameMove, move string) (string, error) {
+	pos, err := m.Position()
+	if err != nil {
+		return "", err
+	}
+	parsers := []func(string) (chess.Move, error){pos.ParseSAN, pos.ParseUCI, pos.ParseLAN}
+	for _, parse := range parsers {
+		if mv, err := parse(move); err == nil {
+			return pos.SAN(mv), nil
+		}
+	}
+	return "", errors.Errorf("premove %s is illegal in position %s", move, m.Fen)
+}
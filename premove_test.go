@@ -0,0 +1,91 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPremoverKeepsMoveWhenSendFails(t *testing.T) {
+	l, err := ListenPipe("premove")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	accepted := make(chan *bufio.Reader, 2)
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- bufio.NewReader(c)
+		}
+	}()
+
+	dial := func() *Conn {
+		conn, err := Dial("pipe://premove", 1, time.Second, false, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return conn
+	}
+
+	// the connection is closed before our move
+	conn := dial()
+	<-accepted
+	conn.Close()
+	client := &Client{config: &Config{DisableTimeseal: true}, conn: conn}
+
+	p := NewPremover(client, 7)
+	failed := make(chan error, 1)
+	p.OnError(func(move string, err error) {
+		failed <- err
+	})
+	p.Queue("e4")
+
+	const board = "<12> rnbqkbnr pppppppp -------- -------- -------- -------- PPPPPPPP RNBQKBNR W -1 1 1 1 1 0 7 Alice Bob 1 3 0 39 39 180 180 1 none (0:00) none 0 0 0"
+	for _, msg := range decodeMessages([]byte(board)) {
+		p.Update(msg)
+	}
+	select {
+	case <-failed:
+	case <-time.After(time.Second):
+		t.Fatal("the failed send is not reported")
+	}
+	if got := p.Pending(); len(got) != 1 || got[0] != "e4" {
+		t.Fatalf("got queue %v after the failed send, want [e4]", got)
+	}
+
+	// the move is sent once the connection is back and the position refreshed
+	client.conn = dial()
+	server := <-accepted
+	sent := make(chan string, 1)
+	p.OnSent(func(move string, latency time.Duration) {
+		sent <- move
+	})
+	for _, msg := range decodeMessages([]byte(board)) {
+		p.Update(msg)
+	}
+	select {
+	case move := <-sent:
+		if move != "e4" {
+			t.Errorf("sent %s, want e4", move)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the queued move is not sent")
+	}
+	line, err := server.ReadString('\n')
+	if err != nil || strings.TrimSpace(line) != "e4" {
+		t.Errorf("server read %q, %v", line, err)
+	}
+	if got := p.Pending(); len(got) != 0 {
+		t.Errorf("got queue %v after sending, want it empty", got)
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DialFunc dials a network connection, like net.Dialer.DialContext
+type DialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// dialDirect dials a network connection without a proxy
+func dialDirect(ctx context.Context, network, addr string) (net.Conn, error) {
+	var d net.Dialer
+	return d.DialContext(ctx, network, addr)
+}
+
+// configDialer returns the function dialing the connections to the server
+// according to the Proxy and Dialer settings of the configuration, or nil
+// if neither is set
+func configDialer(cfg *Config) (DialFunc, error) {
+	if cfg.Proxy == "" {
+		return cfg.Dialer, nil
+	}
+
+	forward := cfg.Dialer
+	if forward == nil {
+		forward = dialDirect
+	}
+
+	u, err := url.Parse(cfg.Proxy)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid proxy %q", cfg.Proxy)
+	}
+
+	switch u.Scheme {
+	case "socks5", "socks5h":
+		return func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialSOCKS5(ctx, forward, u, addr)
+		}, nil
+	case "http":
+		return func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return dialHTTPProxy(ctx, forward, u, addr)
+		}, nil
+	}
+	return nil, errors.Errorf("unsupported proxy scheme %q", u.Scheme)
+}
+
+// hostPort returns the host and port of a URL, with the given default port
+func hostPort(u *url.URL, port string) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// SOCKS5 protocol constants, from RFC 1928 and RFC 1929
+const (
+	socks5Version      = 0x05
+	socks5NoAuth       = 0x00
+	socks5PasswordAuth = 0x02
+	socks5NoMethod     = 0xff
+	socks5Connect      = 0x01
+	socks5IPv4         = 0x01
+	socks5Domain       = 0x03
+	socks5IPv6         = 0x04
+)
+
+// socks5Errors are the reasons a SOCKS5 proxy gives for refusing a request,
+// indexed by reply code
+var socks5Errors = []string{
+	"",
+	"general failure",
+	"connection not allowed by ruleset",
+	"network unreachable",
+	"host unreachable",
+	"connection refused",
+	"TTL expired",
+	"command not supported",
+	"address type not supported",
+}
+
+// dialSOCKS5 opens a connection to addr through a SOCKS5 proxy, which
+// resolves the host name of addr
+func dialSOCKS5(ctx context.Context, forward DialFunc, proxyURL *url.URL, addr string) (net.Conn, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid address %q", addr)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 0xffff {
+		return nil, errors.Errorf("invalid port in address %q", addr)
+	}
+
+	conn, err := forward(ctx, "tcp", hostPort(proxyURL, "1080"))
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if err := socks5Handshake(conn, proxyURL.User, host, port); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// socks5Handshake authenticates with a SOCKS5 proxy and asks it to connect
+// to the given host and port
+func socks5Handshake(conn net.Conn, user *url.Userinfo, host string, port int) error {
+	methods := []byte{socks5NoAuth}
+	if user != nil {
+		methods = append(methods, socks5PasswordAuth)
+	}
+	greeting := append([]byte{socks5Version, byte(len(methods))}, methods...)
+	if _, err := conn.Write(greeting); err != nil {
+		return errors.Wrap(err, "failed to send SOCKS5 greeting to proxy")
+	}
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return errors.Wrap(err, "failed to read SOCKS5 greeting from proxy")
+	}
+	if reply[0] != socks5Version {
+		return errors.Errorf("unexpected SOCKS version %d from proxy", reply[0])
+	}
+
+	switch reply[1] {
+	case socks5NoAuth:
+	case socks5PasswordAuth:
+		if user == nil {
+			return errors.New("SOCKS5 proxy requires credentials")
+		}
+		name := user.Username()
+		password, _ := user.Password()
+		if len(name) > 255 || len(password) > 255 {
+			return errors.New("SOCKS5 credentials are too long")
+		}
+		auth := []byte{0x01, byte(len(name))}
+		auth = append(auth, name...)
+		auth = append(auth, byte(len(password)))
+		auth = append(auth, password...)
+		if _, err := conn.Write(auth); err != nil {
+			return errors.Wrap(err, "failed to send SOCKS5 credentials to proxy")
+		}
+		if _, err := io.ReadFull(conn, reply); err != nil {
+			return errors.Wrap(err, "failed to read SOCKS5 authentication reply from proxy")
+		}
+		if reply[1] != 0x00 {
+			return errors.New("SOCKS5 proxy rejected the credentials")
+		}
+	case socks5NoMethod:
+		return errors.New("SOCKS5 proxy accepts none of our authentication methods")
+	default:
+		return errors.Errorf("unexpected SOCKS5 authentication method %d from proxy", reply[1])
+	}
+
+	req := []byte{socks5Version, socks5Connect, 0x00}
+	if ip := net.ParseIP(host); ip == nil {
+		if len(host) > 255 {
+			return errors.Errorf("host name %q is too long", host)
+		}
+		req = append(req, socks5Domain, byte(len(host)))
+		req = append(req, host...)
+	} else if ip4 := ip.To4(); ip4 != nil {
+		req = append(req, socks5IPv4)
+		req = append(req, ip4...)
+	} else {
+		req = append(req, socks5IPv6)
+		req = append(req, ip.To16()...)
+	}
+	req = append(req, byte(port>>8), byte(port))
+	if _, err := conn.Write(req); err != nil {
+		return errors.Wrap(err, "failed to send SOCKS5 request to proxy")
+	}
+
+	// the reply ends with the address the proxy connected from, of a length
+	// given by its type
+	head := make([]byte, 4)
+	if _, err := io.ReadFull(conn, head); err != nil {
+		return errors.Wrap(err, "failed to read SOCKS5 reply from proxy")
+	}
+	if head[1] != 0x00 {
+		reason := "unknown error"
+		if int(head[1]) < len(socks5Errors) {
+			reason = socks5Errors[head[1]]
+		}
+		return errors.Errorf("SOCKS5 proxy refused to connect to %s: %s", net.JoinHostPort(host, strconv.Itoa(port)), reason)
+	}
+	var n int
+	switch head[3] {
+	case socks5IPv4:
+		n = net.IPv4len
+	case socks5IPv6:
+		n = net.IPv6len
+	case socks5Domain:
+		l := make([]byte, 1)
+		if _, err := io.ReadFull(conn, l); err != nil {
+			return errors.Wrap(err, "failed to read SOCKS5 reply from proxy")
+		}
+		n = int(l[0])
+	default:
+		return errors.Errorf("unexpected SOCKS5 address type %d from proxy", head[3])
+	}
+	if _, err := io.ReadFull(conn, make([]byte, n+2)); err != nil {
+		return errors.Wrap(err, "failed to read SOCKS5 reply from proxy")
+	}
+	return nil
+}
+
+// dialHTTPProxy opens a tunnel to addr through an HTTP proxy with the
+// CONNECT method
+func dialHTTPProxy(ctx context.Context, forward DialFunc, proxyURL *url.URL, addr string) (net.Conn, error) {
+	conn, err := forward(ctx, "tcp", hostPort(proxyURL, "80"))
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	req := &http.Request{
+		Method: "CONNECT",
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if u := proxyURL.User; u != nil {
+		password, _ := u.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(u.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to send CONNECT request to proxy")
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, errors.Wrap(err, "failed to read CONNECT response from proxy")
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		conn.Close()
+		return nil, errors.Errorf("proxy refused to connect to %s: %s", addr, resp.Status)
+	}
+
+	conn.SetDeadline(time.Time{})
+	if br.Buffered() > 0 {
+		// the server may have spoken before we read the response
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn is a connection whose first bytes were already buffered
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
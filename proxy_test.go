@@ -0,0 +1,115 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serveSOCKS5 accepts one connection on a fake SOCKS5 proxy, which requires
+// the given credentials if any, and answers the connect request with rep
+func serveSOCKS5(t *testing.T, user, password string, rep byte) (net.Listener, chan []byte) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests := make(chan []byte, 1)
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+
+		head := make([]byte, 2)
+		io.ReadFull(c, head)
+		methods := make([]byte, head[1])
+		io.ReadFull(c, methods)
+		if user == "" {
+			c.Write([]byte{5, 0})
+		} else if bytes.IndexByte(methods, 2) == -1 {
+			c.Write([]byte{5, 0xff})
+			return
+		} else {
+			c.Write([]byte{5, 2})
+			b := make([]byte, 2)
+			io.ReadFull(c, b)
+			name := make([]byte, b[1])
+			io.ReadFull(c, name)
+			io.ReadFull(c, b[:1])
+			pass := make([]byte, b[0])
+			io.ReadFull(c, pass)
+			if string(name) != user || string(pass) != password {
+				c.Write([]byte{1, 1})
+				return
+			}
+			c.Write([]byte{1, 0})
+		}
+
+		req := make([]byte, 5)
+		io.ReadFull(c, req)
+		rest := make([]byte, int(req[4])+2)
+		io.ReadFull(c, rest)
+		requests <- append(req, rest...)
+		c.Write([]byte{5, rep, 0, 1, 127, 0, 0, 1, 0x13, 0x88})
+		if rep == 0 {
+			c.Write([]byte("login: "))
+		}
+		time.Sleep(100 * time.Millisecond)
+	}()
+	return l, requests
+}
+
+func TestSOCKS5Proxy(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   string
+		proxy  string
+		rep    byte
+		errMsg string
+	}{
+		{"no authentication", "", "socks5://%s", 0, ""},
+		{"password", "alice", "socks5://alice:secret@%s", 0, ""},
+		{"wrong password", "alice", "socks5://alice:guess@%s", 0, "rejected the credentials"},
+		{"no credentials", "alice", "socks5://%s", 0, "none of our authentication methods"},
+		{"refused", "", "socks5h://%s", 5, "connection refused"},
+	}
+	for _, tt := range tests {
+		l, requests := serveSOCKS5(t, tt.user, "secret", tt.rep)
+		dial, err := configDialer(&Config{Proxy: strings.Replace(tt.proxy, "%s", l.Addr().String(), 1)})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		conn, err := dial(ctx, "tcp", "freechess.org:5000")
+		cancel()
+
+		if tt.errMsg != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("%s: got error %v, want %q", tt.name, err, tt.errMsg)
+			}
+		} else if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		} else {
+			want := append([]byte{5, 1, 0, 3, 13}, "freechess.org\x13\x88"...)
+			if got := <-requests; !bytes.Equal(got, want) {
+				t.Errorf("%s: got request %v, want %v", tt.name, got, want)
+			}
+			b := make([]byte, 7)
+			if _, err := io.ReadFull(conn, b); err != nil || string(b) != "login: " {
+				t.Errorf("%s: read %q, %v through the proxy", tt.name, b, err)
+			}
+			conn.Close()
+		}
+		l.Close()
+	}
+}
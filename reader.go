@@ -0,0 +1,367 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net"
+	"time"
+)
+
+const (
+	// maxMessageSize bounds the server output buffered while waiting for the
+	// end of a message; larger output is returned in pieces
+	maxMessageSize = 1 << 20
+	// idleFlush is how long complete lines wait for the prompt ending their
+	// message before they are returned without it
+	idleFlush = 250 * time.Millisecond
+)
+
+// messageReader frames the output of the server into messages. A message
+// ends at a prompt at the start of a line, or when no more output arrives
+// for a while. Lines of interface information, such as style12, and blocks
+// of block mode are messages of their own, returned as soon as they are
+// complete, except that lines which belong together are kept in one message:
+// a movelist with the board it starts from, the start of a game with its
+// game info and first board, and a board with the holdings that follow it
+// in crazyhouse and bughouse
+type messageReader struct {
+	// the start of a timeseal ping, held back until the rest of it arrives
+	held []byte
+	// server output not yet framed
+	buf []byte
+	// complete lines of the message being read
+	msg []byte
+	// the game whose start the message being read holds, if any
+	game string
+	// whether the message being read is a movelist up to its result
+	moveList bool
+	// a message ending with a board, held back until the next line, which
+	// may be the holdings of its game
+	move     []byte
+	moveGame string
+	// games known to send their holdings after every board
+	holdings map[string]bool
+	// messages ready to be returned
+	ready [][]byte
+}
+
+// scan frames the buffered output
+func (r *messageReader) scan(prompt string) {
+	for len(r.buf) > 0 {
+		// r.buf starts at the beginning of a line, or of a block
+		if r.buf[0] == blockStart {
+			i := bytes.IndexByte(r.buf, blockEnd)
+			if i == -1 {
+				break
+			}
+			r.flush()
+			r.ready = append(r.ready, r.buf[:i+1])
+			r.buf = r.buf[i+1:]
+			continue
+		}
+
+		if prompt != "" {
+			if bytes.HasPrefix(r.buf, []byte(prompt)) {
+				r.flush()
+				r.buf = bytes.TrimPrefix(r.buf[len(prompt):], []byte(" "))
+				continue
+			}
+			if len(r.buf) < len(prompt) && bytes.HasPrefix([]byte(prompt), r.buf) {
+				// wait for the rest of what may be a prompt
+				break
+			}
+		}
+
+		i := bytes.IndexByte(r.buf, '\n')
+		if j := bytes.IndexByte(r.buf, blockStart); j != -1 && (i == -1 || j < i) {
+			r.release()
+			r.msg = append(r.msg, r.buf[:j]...)
+			r.buf = r.buf[j:]
+			continue
+		}
+		if i == -1 {
+			break
+		}
+
+		line := r.buf[:i+1]
+		r.buf = r.buf[i+1:]
+		r.frame(line)
+	}
+
+	// a board of a game without known holdings is not held past the output
+	// read with it, unless its holdings are arriving
+	if r.move != nil && !r.holdings[r.moveGame] && !mayBeHoldings(r.buf) {
+		r.release()
+	}
+
+	if len(r.msg)+len(r.buf) > maxMessageSize {
+		r.flush()
+		if len(r.buf) > maxMessageSize {
+			r.ready = append(r.ready, r.buf)
+			r.buf = nil
+		}
+	}
+
+	// the framed output is referenced by the messages, so copy what is left
+	r.buf = append([]byte{}, r.buf...)
+}
+
+// frame adds a complete line to the messages
+func (r *messageReader) frame(line []byte) {
+	r.track(line)
+	if r.move != nil {
+		if len(bytes.TrimSpace(line)) == 0 {
+			return
+		}
+		if bytes.HasPrefix(line, []byte("<b1>")) && infoGame(line) == r.moveGame {
+			r.ready = append(r.ready, append(r.move, line...))
+			r.move = nil
+			return
+		}
+		r.release()
+	}
+
+	if !infoLineRE.Match(line) {
+		switch {
+		case r.moveList:
+			r.msg = append(r.msg, line...)
+			if moveListResultRE.Match(line) {
+				r.flush()
+			}
+		case moveListRE.Match(line):
+			r.flush()
+			r.msg = append(r.msg, line...)
+			r.moveList = true
+		default:
+			if m := gameStartRE.FindSubmatch(line); m != nil {
+				r.flush()
+				r.game = string(m[1])
+			}
+			r.msg = append(r.msg, line...)
+		}
+		return
+	}
+
+	switch {
+	case r.moveList:
+		r.msg = append(r.msg, line...)
+	case r.game != "" && infoGame(line) == r.game && bytes.HasPrefix(line, []byte("<g1>")):
+		r.msg = append(r.msg, line...)
+	case bytes.HasPrefix(line, []byte("<12>")):
+		game := infoGame(line)
+		if r.game == "" || r.game != game {
+			r.flush()
+		}
+		r.move = append(r.msg, line...)
+		r.moveGame = game
+		r.msg, r.game = nil, ""
+	default:
+		r.flush()
+		r.ready = append(r.ready, line)
+	}
+}
+
+// track records the games that send holdings after their boards, as told by
+// the type of game in their start and game info lines or by their holdings,
+// and forgets them when they end or are no longer observed
+func (r *messageReader) track(line []byte) {
+	var game string
+	var holdings bool
+	switch {
+	case bytes.HasPrefix(line, []byte("<b1>")):
+		game, holdings = infoGame(line), true
+	case bytes.HasPrefix(line, []byte("<g1>")):
+		info := decodeGameInfo(bytes.TrimSpace(line))
+		if info == nil {
+			return
+		}
+		game, holdings = infoGame(line), variantHasHoldings([]byte(info.Type))
+	case bytes.Contains(line, []byte("{Game ")):
+		if m := gameStartRE.FindSubmatch(line); m != nil {
+			game, holdings = string(m[1]), variantHasHoldings(line)
+		} else if m := gameEndRE.FindSubmatch(line); m != nil {
+			game = string(m[1])
+		}
+	default:
+		if m := unobserveRE.FindSubmatch(line); m != nil {
+			game = string(m[1]) + string(m[2])
+		}
+	}
+
+	switch {
+	case game == "":
+	case holdings:
+		if r.holdings == nil {
+			r.holdings = make(map[string]bool)
+		}
+		r.holdings[game] = true
+	default:
+		delete(r.holdings, game)
+	}
+}
+
+// variantHasHoldings reports whether the text names a variant with pieces in hand
+func variantHasHoldings(text []byte) bool {
+	return bytes.Contains(text, []byte("crazyhouse")) || bytes.Contains(text, []byte("bughouse"))
+}
+
+// mayBeHoldings reports whether the start of a line may be a holdings line
+func mayBeHoldings(partial []byte) bool {
+	prefix := []byte("<b1>")
+	if len(partial) < len(prefix) {
+		return len(partial) > 0 && bytes.HasPrefix(prefix, partial)
+	}
+	return bytes.HasPrefix(partial, prefix)
+}
+
+// infoGame returns the number of the game a style12, game info or holdings
+// line is about
+func infoGame(line []byte) string {
+	fields := bytes.Fields(line)
+	switch {
+	case len(fields) > 16 && bytes.Equal(fields[0], []byte("<12>")):
+		return string(fields[16])
+	case len(fields) > 1 && bytes.Equal(fields[0], []byte("<g1>")):
+		return string(fields[1])
+	case len(fields) > 2 && bytes.Equal(fields[0], []byte("<b1>")):
+		return string(fields[2])
+	}
+	return ""
+}
+
+// release returns the message held back for the holdings of its game
+func (r *messageReader) release() {
+	if r.move != nil {
+		r.ready = append(r.ready, r.move)
+		r.move = nil
+	}
+}
+
+// flush ends the message being read
+func (r *messageReader) flush() {
+	r.release()
+	if len(bytes.TrimSpace(r.msg)) > 0 {
+		r.ready = append(r.ready, r.msg)
+	}
+	r.msg, r.game, r.moveList = nil, "", false
+}
+
+// pending reports whether output is held back waiting for the rest of its message
+func (r *messageReader) pending() bool {
+	return len(r.msg) > 0 || r.move != nil
+}
+
+// next returns the next message ready to be returned, if any
+func (r *messageReader) next() ([]byte, bool) {
+	if len(r.ready) == 0 {
+		return nil, false
+	}
+	msg := r.ready[0]
+	r.ready = r.ready[1:]
+	return msg, true
+}
+
+// ReadMessage reads the next message from the connection. See ReadMessageContext
+func (c *Conn) ReadMessage(prompt string) ([]byte, error) {
+	return c.ReadMessageContext(context.Background(), prompt)
+}
+
+// ReadMessageContext reads the next message from the connection. Unlike
+// ReadUntilContext, it does not wait for the prompt to return unsolicited
+// output: lines of interface information and blocks are returned as soon as
+// they are complete, and other output once the prompt follows it or the
+// server falls silent. The read is aborted as soon as the context is
+// cancelled or its deadline expires, in which case the context error is returned
+func (c *Conn) ReadMessageContext(ctx context.Context, prompt string) ([]byte, error) {
+	r := &c.reader
+	b := make([]byte, 4096)
+	for {
+		if msg, ok := r.next(); ok {
+			msg = bytes.TrimSpace(c.unwrap(msg))
+			if c.debug {
+				log.Printf("< %s", string(msg))
+			}
+			return msg, nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// give the rest of the message a little time to arrive
+		deadline, _ := ctx.Deadline()
+		var flushAt time.Time
+		if r.pending() {
+			flushAt = time.Now().Add(idleFlush)
+			if deadline.IsZero() || flushAt.Before(deadline) {
+				deadline = flushAt
+			}
+		}
+
+		stop := c.interruptible(ctx, deadline)
+		n, err := c.conn.Read(b)
+		stop()
+
+		if n > 0 {
+			chunk := append(r.held, b[:n]...)
+			r.held = nil
+			if c.timeseal {
+				chunk, r.held = splitPing(c.ackPings(chunk))
+			}
+			r.buf = append(r.buf, clean(chunk)...)
+			r.scan(prompt)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				if !flushAt.IsZero() && !time.Now().Before(flushAt) {
+					r.flush()
+					continue
+				}
+				if !deadline.IsZero() && !time.Now().Before(deadline) {
+					return nil, context.DeadlineExceeded
+				}
+				continue
+			}
+			return nil, err
+		}
+	}
+}
+
+// clean removes the characters that are not part of messages from server output
+func clean(bs []byte) []byte {
+	bs = bytes.Replace(bs, []byte("\u0007"), []byte{}, -1)
+	bs = bytes.Replace(bs, []byte("\x00"), []byte{}, -1)
+	return bytes.Replace(bs, []byte("\r"), []byte{}, -1)
+}
+
+// splitPing splits the start of a timeseal ping cut off by the end of a read
+// from server output, to be completed by the next read
+func splitPing(bs []byte) ([]byte, []byte) {
+	for i := len(timesealPing) - 1; i > 0; i-- {
+		if bytes.HasSuffix(bs, timesealPing[:i]) {
+			return bs[:len(bs)-i], append([]byte{}, bs[len(bs)-i:]...)
+		}
+	}
+	return bs, nil
+}
+
+// ackPings acknowledges and removes the timeseal pings in server output
+func (c *Conn) ackPings(bs []byte) []byte {
+	for {
+		i := bytes.Index(bs, timesealPing)
+		if i == -1 {
+			return bs
+		}
+		c.enqueue(priorityAck, timesealAck, false)
+		bs = append(bs[:i], bs[i+len(timesealPing):]...)
+	}
+}
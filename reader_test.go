@@ -0,0 +1,139 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// output of the server at the start of a crazyhouse game and after its
+// second move, a capture, as read in pieces from the connection
+var crazyhouseOutput = []string{
+	"Creating: GuestABCD (++++) GuestEFGH (++++) unrated crazyhouse 3 0\n" +
+		"{Game 42 (GuestABCD vs. GuestEFGH) Creating unrated crazyhouse match.}\n\n" +
+		"<g1> 42 p=0 t=crazyhouse r=0 u=0,0 it=3,3 i=0,0 pt=0 rt=0,0 ts=1,1\n\n" +
+		"<12> rnbqkbnr pppppppp -------- -------- -------- -------- PPPPPPPP RNBQKBNR W -1 1 1 1 1 0 42 GuestABCD GuestEFGH 1 3 0 39 39 180000 180000 1 none (0:00.000) none 0 0 0\n",
+	"<b1> game 42 white [] black []\nfics% ",
+	"\n<12> rnbqkbnr ppp-pppp -------- ---P---- -------- -------- PPPP-PPP RNBQKBNR B -1 1 1 1 1 0 42 GuestABCD GuestEFGH -1 3 0 39 38 178000 179000 2 P/e4-d5 (0:02.000) exd5 0 1 0\n<b1> game 42 white [P] bl",
+	"ack [] <- WP\nfics% ",
+	"\n" + wildMoveList + "fics% ",
+}
+
+func TestReadMessagesKeepsRelatedLinesTogether(t *testing.T) {
+	l, err := ListenPipe("reader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		for _, out := range crazyhouseOutput {
+			io.WriteString(c, out)
+			time.Sleep(10 * time.Millisecond)
+		}
+		time.Sleep(time.Second)
+	}()
+
+	conn, err := Dial("pipe://reader", 1, time.Second, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := &Client{config: DefaultConfig, conn: conn}
+
+	var msgs []interface{}
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		out, err := conn.ReadMessageContext(ctx, "fics%")
+		cancel()
+		if err != nil {
+			break
+		}
+		msgs = append(msgs, client.decode(out)...)
+	}
+
+	var start *GameStart
+	var moves []*GameMove
+	var ml *MoveList
+	for _, msg := range msgs {
+		switch m := msg.(type) {
+		case *GameStart:
+			start = m
+		case *GameMove:
+			moves = append(moves, m)
+		case *MoveList:
+			ml = m
+		}
+	}
+
+	if start == nil || start.Info == nil || start.Info.Type != "crazyhouse" {
+		t.Errorf("got game start %v, want it with its game info", start)
+	}
+	if len(moves) != 2 {
+		t.Fatalf("got %d moves, want 2", len(moves))
+	}
+	if moves[0].Holdings != "" || !strings.Contains(moves[0].Fen, "[]") {
+		t.Errorf("got holdings %q and FEN %q at the start", moves[0].Holdings, moves[0].Fen)
+	}
+	if moves[1].Holdings != "P" || !strings.Contains(moves[1].Fen, "[P]") {
+		t.Errorf("got holdings %q and FEN %q after exd5, want P", moves[1].Holdings, moves[1].Fen)
+	}
+	if ml == nil || ml.Fen == "" || len(ml.Moves) != 4 {
+		t.Errorf("got movelist %v, want it with its starting position and moves", ml)
+	}
+}
+
+func TestReadMessagesHoldsBoardsOnlyForHoldings(t *testing.T) {
+	const board = "<12> rnbqkbnr pppppppp -------- -------- -------- -------- PPPPPPPP RNBQKBNR W -1 1 1 1 1 0 7 Alice Bob 1 3 0 39 39 180 180 1 none (0:00) none 0 0 0\n"
+	var r messageReader
+
+	// the board of a standard game is returned with the output read with it
+	r.buf = []byte(board)
+	r.scan("fics%")
+	if msg, ok := r.next(); !ok || string(msg) != board {
+		t.Fatalf("got %q, want the board", msg)
+	}
+
+	// unless what follows may be its holdings
+	r.buf = []byte(board + "<b1> ga")
+	r.scan("fics%")
+	if msg, ok := r.next(); ok {
+		t.Fatalf("got %q before the holdings", msg)
+	}
+	r.buf = append(r.buf, "me 7 white [] black []\n"...)
+	r.scan("fics%")
+	if msg, ok := r.next(); !ok || string(msg) != board+"<b1> game 7 white [] black []\n" {
+		t.Fatalf("got %q, want the board with its holdings", msg)
+	}
+
+	// once the game is known to have holdings, its boards wait for them
+	r.buf = []byte(board)
+	r.scan("fics%")
+	if msg, ok := r.next(); ok || !r.pending() {
+		t.Fatalf("got %q, want the board held", msg)
+	}
+	r.buf = []byte("<b1> game 7 white [P] black []\n")
+	r.scan("fics%")
+	if msg, ok := r.next(); !ok || !strings.HasSuffix(string(msg), "[P] black []\n") {
+		t.Fatalf("got %q, want the board with its holdings", msg)
+	}
+
+	// until it ends
+	r.buf = []byte("{Game 7 (Alice vs. Bob) Bob resigns} 1-0\nfics% " + board)
+	r.scan("fics%")
+	r.next()
+	if msg, ok := r.next(); !ok || string(msg) != board {
+		t.Fatalf("got %q, want the board after the end of the game", msg)
+	}
+}
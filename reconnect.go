@@ -0,0 +1,204 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	jitterMu sync.Mutex
+	// seeded, unlike the global source before Go 1.20, so that clients
+	// reconnecting at the same time spread out
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// jitter returns a random duration between zero and max
+func jitter(max time.Duration) time.Duration {
+	jitterMu.Lock()
+	defer jitterMu.Unlock()
+	return time.Duration(jitterRand.Int63n(int64(max) + 1))
+}
+
+// session records the commands that shape a login session, so that they
+// can be replayed after reconnecting
+type session struct {
+	sync.Mutex
+	// keys of the setup commands, in the order they were first sent
+	keys []string
+	// latest setup command sent for each key
+	commands map[string]string
+	// games (or players) being observed, in the order they were observed
+	observed []string
+}
+
+// isCommand reports whether name is an abbreviation of the given FICS
+// command that is at least min characters long
+func isCommand(name, cmd string, min int) bool {
+	return len(name) >= min && strings.HasPrefix(cmd, name)
+}
+
+// record inspects a command sent to the server and remembers it if it
+// changes the session state
+func (s *session) record(cmd string) {
+	fields := strings.Fields(cmd)
+	if len(fields) == 0 {
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	name := strings.ToLower(fields[0])
+	switch {
+	case name == "set" || name == "iset":
+		if len(fields) > 2 {
+			s.setup(name+" "+strings.ToLower(fields[1]), strings.Join(fields, " "))
+		}
+	case len(name) > 1 && (name[0] == '+' || name[0] == '-'):
+		// list commands, such as +channel 50 or -notify somebody
+		if len(fields) > 1 {
+			key := name[1:] + " " + strings.ToLower(strings.Join(fields[1:], " "))
+			s.setup(key, strings.Join(fields, " "))
+		}
+	case isCommand(name, "observe", 2):
+		if len(fields) > 1 {
+			s.forget(fields[1])
+			s.observed = append(s.observed, fields[1])
+		}
+	case isCommand(name, "unobserve", 3):
+		if len(fields) > 1 {
+			s.forget(fields[1])
+		} else {
+			s.observed = nil
+		}
+	}
+}
+
+// update tracks the end of observed games
+func (s *session) update(msg interface{}) {
+	if m, ok := msg.(*GameEnd); ok {
+		s.Lock()
+		s.forget(strconv.FormatUint(uint64(m.GameId), 10))
+		s.Unlock()
+	}
+}
+
+// setup stores the latest command for the given key; the caller must hold the lock
+func (s *session) setup(key, cmd string) {
+	if s.commands == nil {
+		s.commands = make(map[string]string)
+	}
+	if _, ok := s.commands[key]; !ok {
+		s.keys = append(s.keys, key)
+	}
+	s.commands[key] = cmd
+}
+
+// forget stops tracking an observed game; the caller must hold the lock
+func (s *session) forget(game string) {
+	for i, g := range s.observed {
+		if strings.EqualFold(g, game) {
+			s.observed = append(s.observed[:i], s.observed[i+1:]...)
+			return
+		}
+	}
+}
+
+// replay sends the recorded setup commands and re-observes games using the given writer
+func (s *session) replay(write func([]byte) error) error {
+	s.Lock()
+	var cmds []string
+	for _, key := range s.keys {
+		cmds = append(cmds, s.commands[key])
+	}
+	for _, game := range s.observed {
+		cmds = append(cmds, "observe "+game)
+	}
+	s.Unlock()
+
+	for _, cmd := range cmds {
+		if err := write([]byte(cmd)); err != nil {
+			return errors.Wrapf(err, "failed to replay %q", cmd)
+		}
+	}
+	return nil
+}
+
+// reconnect redials the server with exponential backoff and jitter, logs in
+// again and restores the session. It emits a Disconnected event before the
+// first attempt and a Reconnected event once the session is restored
+func (client *Client) reconnect(ctx context.Context, cause error) error {
+	client.emit(ctx, &Disconnected{Reason: cause.Error()})
+
+	cfg := client.config
+	delay := time.Duration(cfg.ReconnectDelay) * time.Second
+	maxDelay := time.Duration(cfg.ReconnectMaxDelay) * time.Second
+
+	var err error
+	for attempt := 1; attempt <= cfg.ConnRetries; attempt++ {
+		wait := delay + jitter(delay/2)
+		log.Printf("reconnecting to ICS server %s in %v (attempt %d of %d)...", client.addr, wait, attempt, cfg.ConnRetries)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		var conn *Conn
+		var username string
+		var n *negotiated
+		conn, username, n, err = connect(ctx, cfg, 1, client.addr, client.loginName, client.password)
+		if err == nil {
+			client.connMu.Lock()
+			old := client.conn
+			client.conn = conn
+			client.username = username
+			client.features = n.features
+			client.icsPrompt = n.prompt
+			client.pending = n.pending
+			client.connMu.Unlock()
+			old.Close()
+
+			if !cfg.DisableKeepAlive {
+				go client.keepAlive(conn)
+			}
+
+			err = client.session.replay(func(cmd []byte) error {
+				_, err := client.write(conn, cmd, nil)
+				return err
+			})
+			if err != nil {
+				log.Printf("restoring session: %v", err)
+			}
+
+			client.emit(ctx, &Reconnected{
+				Attempts: uint32(attempt),
+				Username: username,
+			})
+			return nil
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		delay *= 2
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+
+	return errors.Wrap(err, "failed to reconnect")
+}
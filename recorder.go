@@ -0,0 +1,266 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/freechessclub/icsgo/chess"
+)
+
+// recording is a game being recorded
+type recording struct {
+	ml *MoveList
+	// the number of half-moves played in the latest position received
+	ply int
+	// the latest position received while waiting for missed moves
+	last *GameMove
+	// whether a movelist was requested to fill in missed moves
+	backfill bool
+	ended    bool
+	// whether the game was joined from a bare GameMove, i.e. observed or
+	// examined rather than played
+	observed bool
+}
+
+// GameRecorder records the games we play or observe from their GameStart,
+// GameMove and GameEnd messages, and exports each of them in PGN format once
+// it is over. Moves missed along the way, for instance when observing a game
+// that is already in progress, are filled in with a moves request
+type GameRecorder struct {
+	mu         sync.Mutex
+	client     *Client
+	games      map[uint32]*recording
+	onFinished []func(ml *MoveList, pgn string)
+}
+
+// NewGameRecorder creates a new game recorder. The recorder must be fed with
+// the messages received from the server, for instance by registering its
+// Update method with Client.OnEvent
+func NewGameRecorder(client *Client) *GameRecorder {
+	return &GameRecorder{
+		client: client,
+		games:  make(map[uint32]*recording),
+	}
+}
+
+// OnFinished registers a callback invoked with the complete move list of a
+// game and its PGN export when the game ends
+func (r *GameRecorder) OnFinished(fn func(ml *MoveList, pgn string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onFinished = append(r.onFinished, fn)
+}
+
+// Update applies a message received from the server to the games being
+// recorded; messages other than GameStart, GameMove, GameEnd, MoveList,
+// GameUnobserved and Disconnected are ignored. Observed and examined games
+// are dropped when we stop observing or examining them, or are disconnected
+func (r *GameRecorder) Update(msg interface{}) {
+	r.mu.Lock()
+
+	var backfill uint32
+	var finished *MoveList
+	switch m := msg.(type) {
+	case *GameStart:
+		r.start(m)
+	case *GameMove:
+		if r.move(m) {
+			backfill = m.GameId
+		}
+	case *MoveList:
+		finished = r.fill(m)
+	case *GameEnd:
+		finished = r.end(m)
+	case *GameUnobserved:
+		if g, ok := r.games[m.GameId]; ok && g.observed {
+			delete(r.games, m.GameId)
+		}
+	case *Disconnected:
+		for id, g := range r.games {
+			if g.observed {
+				delete(r.games, id)
+			}
+		}
+	}
+
+	callbacks := r.onFinished
+	r.mu.Unlock()
+
+	if backfill != 0 {
+		if err := r.client.Moves(backfill); err != nil {
+			log.Printf("requesting moves of game %d: %v", backfill, err)
+		}
+	}
+	if finished != nil {
+		pgn := finished.ToPGN()
+		for _, fn := range callbacks {
+			fn(finished, pgn)
+		}
+	}
+}
+
+// start begins recording a game; the caller must hold the lock
+func (r *GameRecorder) start(m *GameStart) {
+	ml := &MoveList{
+		GameId:    m.GameId,
+		WhiteName: m.PlayerOne,
+		BlackName: m.PlayerTwo,
+		Date:      time.Now().Format("Mon Jan _2, 15:04 MST 2006"),
+	}
+	if info := m.Info; info != nil {
+		ml.Rated = info.Rated
+		ml.Type = info.Type
+		ml.Time = info.WhiteInitialTime
+		ml.Inc = info.WhiteInc
+		if info.WhiteRating > 0 {
+			ml.WhiteRating = strconv.FormatUint(uint64(info.WhiteRating), 10)
+		}
+		if info.BlackRating > 0 {
+			ml.BlackRating = strconv.FormatUint(uint64(info.BlackRating), 10)
+		}
+	}
+	r.games[m.GameId] = &recording{ml: ml}
+}
+
+// move records a move, reporting whether moves were missed and must be
+// requested from the server; the caller must hold the lock
+func (r *GameRecorder) move(m *GameMove) bool {
+	g, ok := r.games[m.GameId]
+	if !ok {
+		// a game observed after it started
+		g = &recording{ml: &MoveList{
+			GameId:    m.GameId,
+			WhiteName: m.WhiteName,
+			BlackName: m.BlackName,
+			Date:      time.Now().Format("Mon Jan _2, 15:04 MST 2006"),
+		}, observed: true}
+		r.games[m.GameId] = g
+	}
+	if g.ended {
+		return false
+	}
+	if g.ml.Time == 0 && g.ml.Inc == 0 {
+		g.ml.Time, g.ml.Inc = m.Time, m.Inc
+	}
+
+	ply := int(m.MoveNo-1) * 2
+	if m.Turn == "B" {
+		ply++
+	}
+	moves := len(g.ml.Moves)
+	switch {
+	case g.backfill:
+		// the movelist requested will bring the game up to date
+		g.ply = ply
+		g.last = m
+		return false
+	case ply == 0:
+		g.ml.Moves = nil
+		if m.Fen != chess.StartFEN {
+			g.ml.Fen = m.Fen
+		}
+	case ply <= moves+1:
+		// the next move, or a position after a takeback
+		g.ml.Moves = append(g.ml.Moves[:ply-1], &MoveList_Move{
+			San:   m.Move,
+			Time:  m.MoveTime,
+			Clock: moverClock(m),
+		})
+	default:
+		g.ply = ply
+		g.backfill = true
+		return true
+	}
+	g.ply = ply
+	return false
+}
+
+// moverClock returns the clock, in milliseconds, of the player who made the
+// last move in the given position, or 0 if it ran out
+func moverClock(m *GameMove) uint32 {
+	clock := m.WhiteTime
+	if m.Turn == "W" {
+		clock = m.BlackTime
+	}
+	if clock < 0 {
+		return 0
+	}
+	if !m.Ms {
+		clock *= 1000
+	}
+	return uint32(clock)
+}
+
+// fill merges a movelist requested to fill in missed moves, returning the
+// recorded game if it ended while waiting for the movelist; the caller must
+// hold the lock
+func (r *GameRecorder) fill(ml *MoveList) *MoveList {
+	g, ok := r.games[ml.GameId]
+	if !ok || !g.backfill {
+		return nil
+	}
+	g.backfill = false
+
+	// the movelist has the time taken for every move, but keep the clocks
+	// of the moves that we received ourselves
+	moves := make([]*MoveList_Move, len(ml.Moves))
+	for i, m := range ml.Moves {
+		moves[i] = &MoveList_Move{San: m.San, Time: m.Time}
+		if i < len(g.ml.Moves) && g.ml.Moves[i].San == m.San {
+			moves[i].Clock = g.ml.Moves[i].Clock
+		}
+	}
+	// a move may have been played since the movelist was sent
+	if g.last != nil && g.ply == len(moves)+1 {
+		moves = append(moves, &MoveList_Move{
+			San:   g.last.Move,
+			Time:  g.last.MoveTime,
+			Clock: moverClock(g.last),
+		})
+	}
+	g.ml.Moves = moves
+	g.last = nil
+
+	g.ml.WhiteName, g.ml.BlackName = ml.WhiteName, ml.BlackName
+	g.ml.WhiteRating, g.ml.BlackRating = ml.WhiteRating, ml.BlackRating
+	g.ml.Date, g.ml.Rated, g.ml.Type = ml.Date, ml.Rated, ml.Type
+	g.ml.Time, g.ml.Inc = ml.Time, ml.Inc
+	if ml.Fen != "" {
+		g.ml.Fen = ml.Fen
+	}
+
+	if g.ended {
+		return r.finish(ml.GameId)
+	}
+	return nil
+}
+
+// end records the result of a game, returning the recorded game unless we are
+// still waiting for missed moves; the caller must hold the lock
+func (r *GameRecorder) end(m *GameEnd) *MoveList {
+	g, ok := r.games[m.GameId]
+	if !ok || g.ended {
+		return nil
+	}
+	g.ended = true
+	g.ml.Result = m.Result
+	g.ml.Termination = m.Termination
+	if g.backfill {
+		return nil
+	}
+	return r.finish(m.GameId)
+}
+
+// finish stops recording a game and returns it; the caller must hold the lock
+func (r *GameRecorder) finish(game uint32) *MoveList {
+	g := r.games[game]
+	delete(r.games, game)
+	return g.ml
+}
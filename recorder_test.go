@@ -0,0 +1,42 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import "testing"
+
+func TestRecorderDropsUnobservedGames(t *testing.T) {
+	const board = "<12> rnbqkbnr pppppppp -------- -------- -------- -------- PPPPPPPP RNBQKBNR W -1 1 1 1 1 0 5 Alice Bob 0 3 0 39 39 180 180 1 none (0:00) none 0 0 0"
+
+	for _, tc := range []struct {
+		name string
+		end  string
+	}{
+		{"unobserve", "Removing game 5 from observation list."},
+		{"unexamine", "You are no longer examining game 5."},
+		{"disconnect", ""},
+	} {
+		r := NewGameRecorder(nil)
+		for _, msg := range decodeMessages([]byte(board)) {
+			r.Update(msg)
+		}
+		if len(r.games) != 1 {
+			t.Fatalf("%s: the observed game is not recorded", tc.name)
+		}
+
+		var msgs []interface{}
+		if tc.end == "" {
+			msgs = []interface{}{&Disconnected{Reason: "EOF"}}
+		} else {
+			msgs = decodeMessages([]byte(tc.end))
+		}
+		for _, msg := range msgs {
+			r.Update(msg)
+		}
+		if len(r.games) != 0 {
+			t.Errorf("%s: the recording of the game was not dropped", tc.name)
+		}
+	}
+}
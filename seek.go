@@ -0,0 +1,222 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	seekAddRE    *regexp.Regexp
+	seekRemoveRE *regexp.Regexp
+	seekClearRE  *regexp.Regexp
+)
+
+// title flags of a seek ad
+const (
+	TitleUnregistered = 0x01
+	TitleComputer     = 0x02
+	TitleGM           = 0x04
+	TitleIM           = 0x08
+	TitleFM           = 0x10
+	TitleWGM          = 0x20
+	TitleWIM          = 0x40
+	TitleWFM          = 0x80
+)
+
+func init() {
+	// <s> 8 w=visar ti=02 rt=2194  t=4 i=0 r=r tp=suicide c=? rr=0-9999 a=t f=f
+	seekAddRE = regexp.MustCompile(`^<s>\s+([0-9]+)\s+(.*)$`)
+
+	// <sr> 8 11 19
+	seekRemoveRE = regexp.MustCompile(`^<sr>((?:\s+[0-9]+)+)\s*$`)
+
+	// <sc>
+	seekClearRE = regexp.MustCompile(`^<sc>\s*$`)
+}
+
+// parseKeyValues parses space-separated key=value pairs
+func parseKeyValues(b []byte) map[string]string {
+	kv := make(map[string]string)
+	for _, f := range strings.Fields(string(b)) {
+		i := strings.IndexByte(f, '=')
+		if i == -1 {
+			continue
+		}
+		kv[f[:i]] = f[i+1:]
+	}
+	return kv
+}
+
+// parseRating parses a rating with an optional provisional or estimated marker
+func parseRating(s string) (uint32, string) {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if i == -1 {
+		return unsafeAtoi([]byte(s)), ""
+	}
+	return unsafeAtoi([]byte(s[:i])), s[i:]
+}
+
+func decodeSeekMessage(msg []byte) interface{} {
+	if !bytes.HasPrefix(msg, []byte("<s")) {
+		return nil
+	}
+
+	matches := seekAddRE.FindSubmatch(msg)
+	if matches != nil && len(matches) > 2 {
+		kv := parseKeyValues(matches[2])
+		titles, _ := strconv.ParseUint(kv["ti"], 16, 32)
+		rating, flag := parseRating(kv["rt"])
+		s := &SeekAdd{
+			Index:          unsafeAtoi(matches[1]),
+			Handle:         kv["w"],
+			Titles:         uint32(titles),
+			Rating:         rating,
+			RatingFlag:     flag,
+			Time:           unsafeAtoi([]byte(kv["t"])),
+			Inc:            unsafeAtoi([]byte(kv["i"])),
+			Rated:          kv["r"] == "r",
+			Type:           kv["tp"],
+			Color:          kv["c"],
+			Automatic:      kv["a"] == "t",
+			FormulaChecked: kv["f"] == "t",
+		}
+		if rr := strings.SplitN(kv["rr"], "-", 2); len(rr) == 2 {
+			s.RatingMin = unsafeAtoi([]byte(rr[0]))
+			s.RatingMax = unsafeAtoi([]byte(rr[1]))
+		}
+		return s
+	}
+
+	matches = seekRemoveRE.FindSubmatch(msg)
+	if matches != nil && len(matches) > 1 {
+		s := &SeekRemove{}
+		for _, f := range bytes.Fields(matches[1]) {
+			s.Indices = append(s.Indices, unsafeAtoi(f))
+		}
+		return s
+	}
+
+	if seekClearRE.Match(msg) {
+		return &SeekClear{}
+	}
+
+	return nil
+}
+
+// SeekFilter reports whether a seek ad should be included in a query
+type SeekFilter func(*SeekAdd) bool
+
+// SeekRated matches rated or unrated seek ads
+func SeekRated(rated bool) SeekFilter {
+	return func(s *SeekAdd) bool {
+		return s.Rated == rated
+	}
+}
+
+// SeekTypes matches seek ads for any of the given types of game
+func SeekTypes(types ...string) SeekFilter {
+	return func(s *SeekAdd) bool {
+		for _, t := range types {
+			if strings.EqualFold(s.Type, t) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SeekRatingBetween matches seek ads from players rated between min and max, inclusive
+func SeekRatingBetween(min, max uint32) SeekFilter {
+	return func(s *SeekAdd) bool {
+		return s.Rating >= min && s.Rating <= max
+	}
+}
+
+// SeekAcceptsRating matches seek ads open to an opponent with the given rating
+func SeekAcceptsRating(rating uint32) SeekFilter {
+	return func(s *SeekAdd) bool {
+		return rating >= s.RatingMin && rating <= s.RatingMax
+	}
+}
+
+// SeekGraph keeps the set of seek ads on the server in sync with the
+// SeekAdd, SeekRemove and SeekClear messages it is updated with
+type SeekGraph struct {
+	mu    sync.RWMutex
+	seeks map[uint32]*SeekAdd
+}
+
+// NewSeekGraph creates a new, empty, seek graph
+func NewSeekGraph() *SeekGraph {
+	return &SeekGraph{
+		seeks: make(map[uint32]*SeekAdd),
+	}
+}
+
+// Update applies a message to the seek graph; messages other than SeekAdd,
+// SeekRemove and SeekClear are ignored, so Update can be registered with
+// Client.OnEvent directly
+func (g *SeekGraph) Update(msg interface{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	switch m := msg.(type) {
+	case *SeekAdd:
+		g.seeks[m.Index] = m
+	case *SeekRemove:
+		for _, i := range m.Indices {
+			delete(g.seeks, i)
+		}
+	case *SeekClear:
+		g.seeks = make(map[uint32]*SeekAdd)
+	}
+}
+
+// Get returns the seek ad with the given index, or nil if there is none
+func (g *SeekGraph) Get(index uint32) *SeekAdd {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.seeks[index]
+}
+
+// Len returns the number of seek ads in the graph
+func (g *SeekGraph) Len() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return len(g.seeks)
+}
+
+// Seeks returns the seek ads matching all of the given filters, ordered by index
+func (g *SeekGraph) Seeks(filters ...SeekFilter) []*SeekAdd {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	var seeks []*SeekAdd
+	for _, s := range g.seeks {
+		match := true
+		for _, f := range filters {
+			if !f(s) {
+				match = false
+				break
+			}
+		}
+		if match {
+			seeks = append(seeks, s)
+		}
+	}
+
+	sort.Slice(seeks, func(i, j int) bool {
+		return seeks[i].Index < seeks[j].Index
+	})
+	return seeks
+}
@@ -0,0 +1,102 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDecodeSeekMessages(t *testing.T) {
+	tests := []struct {
+		line string
+		want proto.Message
+	}{
+		{
+			"<s> 8 w=visar ti=02 rt=2194  t=4 i=0 r=r tp=suicide c=? rr=0-9999 a=t f=f",
+			&SeekAdd{
+				Index:     8,
+				Handle:    "visar",
+				Titles:    TitleComputer,
+				Rating:    2194,
+				Time:      4,
+				Rated:     true,
+				Type:      "suicide",
+				Color:     "?",
+				RatingMax: 9999,
+				Automatic: true,
+			},
+		},
+		{
+			"<s> 12 w=GuestXQJV ti=01 rt=0P t=5 i=2 r=u tp=blitz c=W rr=0-9999 a=f f=f",
+			&SeekAdd{
+				Index:      12,
+				Handle:     "GuestXQJV",
+				Titles:     TitleUnregistered,
+				RatingFlag: "P",
+				Time:       5,
+				Inc:        2,
+				Type:       "blitz",
+				Color:      "W",
+				RatingMax:  9999,
+			},
+		},
+		{
+			"<s> 101 w=Shirov ti=04 rt=1820E t=2 i=12 r=r tp=crazyhouse c=B rr=1500-2100 a=t f=t",
+			&SeekAdd{
+				Index:          101,
+				Handle:         "Shirov",
+				Titles:         TitleGM,
+				Rating:         1820,
+				RatingFlag:     "E",
+				Time:           2,
+				Inc:            12,
+				Rated:          true,
+				Type:           "crazyhouse",
+				Color:          "B",
+				RatingMin:      1500,
+				RatingMax:      2100,
+				Automatic:      true,
+				FormulaChecked: true,
+			},
+		},
+		{"<sr> 8 11 19", &SeekRemove{Indices: []uint32{8, 11, 19}}},
+		{"<sr> 4", &SeekRemove{Indices: []uint32{4}}},
+		{"<sc>", &SeekClear{}},
+	}
+	for _, tt := range tests {
+		got, ok := decodeOne(t, tt.line).(proto.Message)
+		if !ok || !proto.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeSeeksBetweenOtherOutput(t *testing.T) {
+	chunk := "<sr> 8\n" +
+		"Alice(50): I am looking for a long game, anyone interested in a 45 45\n" +
+		"\\   game? Please send me a match request.\n" +
+		"<s> 9 w=Alice ti=00 rt=1500  t=45 i=45 r=r tp=standard c=? rr=0-9999 a=t f=f"
+	msgs := decodeMessages([]byte(chunk))
+	if len(msgs) != 3 {
+		t.Fatalf("decoded %d messages, want 3: %v", len(msgs), msgs)
+	}
+	if _, ok := msgs[0].(*SeekRemove); !ok {
+		t.Errorf("got %T, want *SeekRemove", msgs[0])
+	}
+	tell, ok := msgs[1].(*ChannelTell)
+	if !ok {
+		t.Fatalf("got %T, want *ChannelTell", msgs[1])
+	}
+	if tell.User != "Alice" || tell.Channel != "50" || !strings.HasSuffix(tell.Message, "match request.") {
+		t.Errorf("got %v", tell)
+	}
+	if _, ok := msgs[2].(*SeekAdd); !ok {
+		t.Errorf("got %T, want *SeekAdd", msgs[2])
+	}
+}
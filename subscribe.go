@@ -0,0 +1,210 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"context"
+	"sync"
+)
+
+// Event is a decoded message received from the ICS server, such as a
+// *GameMove or a *ChannelTell
+type Event interface{}
+
+// EventFilter reports whether an event should be delivered to a subscriber
+type EventFilter func(Event) bool
+
+// OverflowPolicy decides what happens when a subscriber's buffer is full
+type OverflowPolicy int
+
+// overflow policies supported by subscriptions
+const (
+	// OverflowDropOldest discards the oldest buffered event to make room
+	OverflowDropOldest OverflowPolicy = iota
+	// OverflowBlock waits for the subscriber to make room, stalling the
+	// delivery of events to every other subscriber and callback
+	OverflowBlock
+	// OverflowDisconnect closes the subscriber's channel and removes it
+	OverflowDisconnect
+)
+
+const defaultSubscriberBuffer = 64
+
+// SubscribeOptions represents the options of a subscription
+type SubscribeOptions struct {
+	BufferSize int
+	Overflow   OverflowPolicy
+}
+
+type subscriber struct {
+	ch     chan Event
+	filter EventFilter
+	policy OverflowPolicy
+	// closed when the subscriber is removed, to abort a blocked send
+	done chan struct{}
+	// held while sending, so that the channel is never closed mid-send
+	sendMu sync.Mutex
+	closed bool
+	once   sync.Once
+}
+
+// bus fans out events to subscribers
+type bus struct {
+	mu   sync.Mutex
+	subs []*subscriber
+	// whether the client was destroyed, after which subscriptions are closed
+	// as soon as they are created
+	closed bool
+}
+
+// Subscribe returns a channel on which every event matching the filter is
+// delivered while Run is active. A nil filter matches all events, and nil
+// options use a buffer of 64 events with the OverflowDropOldest policy. The
+// channel is closed when Run returns, when the client is destroyed, when the
+// subscriber is removed with Unsubscribe, or when it overflows with the
+// OverflowDisconnect policy
+func (client *Client) Subscribe(filter EventFilter, opts *SubscribeOptions) <-chan Event {
+	size := defaultSubscriberBuffer
+	policy := OverflowDropOldest
+	if opts != nil {
+		if opts.BufferSize > 0 {
+			size = opts.BufferSize
+		}
+		policy = opts.Overflow
+	}
+
+	s := &subscriber{
+		ch:     make(chan Event, size),
+		filter: filter,
+		policy: policy,
+		done:   make(chan struct{}),
+	}
+
+	client.bus.mu.Lock()
+	defer client.bus.mu.Unlock()
+	if client.bus.closed {
+		s.close()
+		return s.ch
+	}
+	client.bus.subs = append(client.bus.subs, s)
+	return s.ch
+}
+
+// Unsubscribe removes the subscriber that owns the given channel and closes it
+func (client *Client) Unsubscribe(ch <-chan Event) {
+	client.bus.mu.Lock()
+	var found *subscriber
+	for _, s := range client.bus.subs {
+		if s.ch == ch {
+			found = s
+			break
+		}
+	}
+	client.bus.mu.Unlock()
+	if found != nil {
+		client.bus.remove(found)
+	}
+}
+
+// publish delivers the event to all matching subscribers, applying each
+// subscriber's overflow policy. The subscribers are sent the event without
+// holding the lock of the bus, so that a blocked subscriber can still
+// unsubscribe
+func (b *bus) publish(ctx context.Context, ev Event) {
+	b.mu.Lock()
+	subs := append([]*subscriber{}, b.subs...)
+	b.mu.Unlock()
+
+	for _, s := range subs {
+		if s.filter != nil && !s.filter(ev) {
+			continue
+		}
+		if !s.send(ctx, ev) {
+			b.remove(s)
+		}
+	}
+}
+
+// send delivers the event to the subscriber, returning false if it must be
+// removed because it overflowed with the OverflowDisconnect policy
+func (s *subscriber) send(ctx context.Context, ev Event) bool {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if s.closed {
+		return true
+	}
+
+	select {
+	case s.ch <- ev:
+		return true
+	default:
+	}
+
+	switch s.policy {
+	case OverflowBlock:
+		select {
+		case s.ch <- ev:
+		case <-s.done:
+		case <-ctx.Done():
+		}
+	case OverflowDisconnect:
+		return false
+	default:
+		for {
+			select {
+			case s.ch <- ev:
+			default:
+				select {
+				case <-s.ch:
+				default:
+				}
+				continue
+			}
+			break
+		}
+	}
+	return true
+}
+
+// close closes the channel of the subscriber, waiting for a send in progress
+// to be aborted
+func (s *subscriber) close() {
+	s.once.Do(func() {
+		close(s.done)
+		s.sendMu.Lock()
+		defer s.sendMu.Unlock()
+		s.closed = true
+		close(s.ch)
+	})
+}
+
+// closeAll closes and removes all subscribers. Once the bus is shut, new
+// subscribers are closed as soon as they subscribe
+func (b *bus) closeAll(shut bool) {
+	b.mu.Lock()
+	subs := b.subs
+	b.subs = nil
+	if shut {
+		b.closed = true
+	}
+	b.mu.Unlock()
+	for _, s := range subs {
+		s.close()
+	}
+}
+
+// remove closes and removes a subscriber
+func (b *bus) remove(s *subscriber) {
+	b.mu.Lock()
+	for i, sub := range b.subs {
+		if sub == s {
+			b.subs = append(b.subs[:i], b.subs[i+1:]...)
+			break
+		}
+	}
+	b.mu.Unlock()
+	s.close()
+}
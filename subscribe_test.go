@@ -0,0 +1,61 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestUnsubscribeBlockedSubscriber(t *testing.T) {
+	client := &Client{}
+	ch := client.Subscribe(nil, &SubscribeOptions{BufferSize: 1, Overflow: OverflowBlock})
+
+	published := make(chan struct{})
+	go func() {
+		client.bus.publish(context.Background(), &Message{Message: "one"})
+		client.bus.publish(context.Background(), &Message{Message: "two"})
+		close(published)
+	}()
+
+	// wait for the second event to block on the full buffer
+	time.Sleep(50 * time.Millisecond)
+
+	unsubscribed := make(chan struct{})
+	go func() {
+		client.Unsubscribe(ch)
+		close(unsubscribed)
+	}()
+
+	for _, done := range []chan struct{}{unsubscribed, published} {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Unsubscribe deadlocked with a blocked publish")
+		}
+	}
+
+	if ev := <-ch; ev.(*Message).Message != "one" {
+		t.Errorf("got %v, want the first event", ev)
+	}
+	if _, ok := <-ch; ok {
+		t.Error("channel was not closed")
+	}
+}
+
+func TestSubscribeAfterDestroyIsClosed(t *testing.T) {
+	client := &Client{}
+	before := client.Subscribe(nil, nil)
+	client.bus.closeAll(true)
+
+	if _, ok := <-before; ok {
+		t.Error("subscription was not closed when the client was destroyed")
+	}
+	if _, ok := <-client.Subscribe(nil, nil); ok {
+		t.Error("subscription created after the client was destroyed is open")
+	}
+}
@@ -6,14 +6,29 @@
 package icsgo
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/pkg/errors"
 )
 
 const (
 	tsKey = "Timestamp (FICS) v1.0 - programmed by Henrik Gram."
 )
 
+var (
+	// timesealPing is sent by the server to measure the lag of the client
+	timesealPing = []byte{'[', 'G', ']', 0x00}
+	// timesealAck is the reply of the client to a ping
+	timesealAck = []byte{0x02, 0x39}
+)
+
 // Encode a byte array using the encoding scheme mandated by timeseal2
 func encode(b []byte, l int) []byte {
 	s := make([]byte, l+30)
@@ -52,3 +67,147 @@ func encode(b []byte, l int) []byte {
 	l++
 	return s[:l]
 }
+
+// decode decodes a message encoded by encode, including its terminating
+// 0x80 0x0a, returning the message and the timestamp of the client, in
+// milliseconds
+func decode(b []byte) ([]byte, int64, error) {
+	if !bytes.HasSuffix(b, []byte{0x80, 0x0a}) {
+		return nil, 0, errors.New("timeseal message is not terminated")
+	}
+	l := len(b) - 2
+	if l == 0 || l%12 != 0 {
+		return nil, 0, errors.Errorf("invalid timeseal message length %d", l)
+	}
+
+	s := make([]byte, l)
+	for n := 0; n < l; n++ {
+		s[n] = ((b[n] + 32) ^ tsKey[n%50]) &^ 0x80
+	}
+
+	for n := 0; n < l; n += 12 {
+		s[n], s[n+11] = s[n+11], s[n]
+		s[n+2], s[n+9] = s[n+9], s[n+2]
+		s[n+4], s[n+7] = s[n+7], s[n+4]
+	}
+
+	// the message ends with 0x18, the timestamp, 0x19 and up to 11 bytes of
+	// padding, so the trailer is parsed from the end, whatever the message
+	end := len(s) - 1
+	for pad := 0; end >= 0 && s[end] == '1' && pad < 11; pad++ {
+		end--
+	}
+	if end < 0 || s[end] != 0x19 {
+		return nil, 0, errors.New("invalid timeseal message padding")
+	}
+	start := end - 1
+	for start >= 0 && s[start] >= '0' && s[start] <= '9' {
+		start--
+	}
+	if start < 0 || s[start] != 0x18 {
+		return nil, 0, errors.New("timeseal message has no timestamp")
+	}
+	ts, err := strconv.ParseInt(string(s[start+1:end]), 10, 64)
+	if err != nil {
+		return nil, 0, errors.Errorf("invalid timeseal timestamp %q", s[start+1:end])
+	}
+	return s[:start], ts, nil
+}
+
+// InvalidCommandError is returned by ReadCommand for a line sent by the
+// client that is not a valid timeseal message. The line is skipped, and the
+// next command can be read
+type InvalidCommandError struct {
+	Line []byte
+	Err  error
+}
+
+func (e *InvalidCommandError) Error() string {
+	return "invalid timeseal command: " + e.Err.Error()
+}
+
+// TimesealConn is the server side of a connection from a timeseal client,
+// for servers and proxies that speak timeseal. Commands sent by the client
+// are decoded by ReadCommand, while output written to the client is sent
+// as it is
+type TimesealConn struct {
+	net.Conn
+	r *bufio.Reader
+
+	mu sync.Mutex
+	// the client identification sent with the hello
+	hello string
+	// when the last unanswered ping was sent
+	pinged time.Time
+	// client timestamp and round trip time of the last answered ping
+	ack int64
+	lag time.Duration
+}
+
+// NewTimesealConn creates the server side of a timeseal connection
+func NewTimesealConn(conn net.Conn) *TimesealConn {
+	return &TimesealConn{
+		Conn: conn,
+		r:    bufio.NewReader(conn),
+	}
+}
+
+// ReadCommand reads the next command sent by the client, returning it with
+// the client timestamp at which it was sent, in milliseconds. The hello and
+// the replies to pings are handled and not returned. A line that cannot be
+// decoded is returned as an InvalidCommandError, after which the connection
+// can still be read
+func (c *TimesealConn) ReadCommand() ([]byte, int64, error) {
+	for {
+		line, err := c.r.ReadBytes('\n')
+		if err != nil {
+			return nil, 0, err
+		}
+		msg, ts, err := decode(line)
+		if err != nil {
+			return nil, 0, &InvalidCommandError{Line: line, Err: err}
+		}
+
+		switch {
+		case bytes.Equal(msg, timesealAck):
+			c.mu.Lock()
+			if !c.pinged.IsZero() {
+				c.lag = time.Since(c.pinged)
+				c.pinged = time.Time{}
+			}
+			c.ack = ts
+			c.mu.Unlock()
+		case bytes.HasPrefix(msg, []byte("TIMESEAL2|")):
+			c.mu.Lock()
+			c.hello = strings.TrimPrefix(string(msg), "TIMESEAL2|")
+			c.mu.Unlock()
+		default:
+			return msg, ts, nil
+		}
+	}
+}
+
+// Ping asks the client for its timestamp; the reply is read by ReadCommand
+func (c *TimesealConn) Ping() error {
+	c.mu.Lock()
+	c.pinged = time.Now()
+	c.mu.Unlock()
+	_, err := c.Write(timesealPing)
+	return err
+}
+
+// Hello returns the client identification sent with the hello, such as
+// freeseal|icsgo|, or an empty string if no hello was received
+func (c *TimesealConn) Hello() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hello
+}
+
+// LastAck returns the client timestamp of the last reply to a ping and the
+// time the reply took to arrive
+func (c *TimesealConn) LastAck() (int64, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ack, c.lag
+}
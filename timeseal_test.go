@@ -0,0 +1,74 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"bytes"
+	"math/rand"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestTimesealRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	for i := 0; i < 20000; i++ {
+		msg := make([]byte, r.Intn(200))
+		for j := range msg {
+			msg[j] = byte(' ' + r.Intn('~'-' '+1))
+		}
+		got, ts, err := decode(encode(msg, len(msg)))
+		if err != nil {
+			t.Fatalf("%q: %v", msg, err)
+		}
+		if !bytes.Equal(got, msg) {
+			t.Fatalf("got %q, want %q", got, msg)
+		}
+		if ts < now || ts > now+60000 {
+			t.Fatalf("%q: got timestamp %d, want about %d", msg, ts, now)
+		}
+	}
+}
+
+func TestTimesealDecodeMarkersInMessage(t *testing.T) {
+	for _, msg := range [][]byte{
+		{'a', 0x19, '1'},
+		{0x18, '1', '2', 0x19},
+		{'t', 'e', 'l', 'l', 0x18, '5', 0x19, '1', '1'},
+	} {
+		got, _, err := decode(encode(msg, len(msg)))
+		if err != nil || !bytes.Equal(got, msg) {
+			t.Errorf("got %q, %v, want %q", got, err, msg)
+		}
+	}
+}
+
+func TestReadCommandSkipsInvalidLines(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	tc := NewTimesealConn(server)
+	defer tc.Close()
+
+	go func() {
+		client.Write(encode([]byte("TIMESEAL2|freeseal|icsgo|"), 25))
+		client.Write([]byte("not timeseal\n"))
+		client.Write(encode([]byte("finger"), 6))
+	}()
+
+	if _, _, err := tc.ReadCommand(); err == nil {
+		t.Fatal("the invalid line was not reported")
+	} else if _, ok := err.(*InvalidCommandError); !ok {
+		t.Fatalf("got error %v, want an InvalidCommandError", err)
+	}
+	cmd, _, err := tc.ReadCommand()
+	if err != nil || string(cmd) != "finger" {
+		t.Errorf("got %q, %v after the invalid line, want finger", cmd, err)
+	}
+	if tc.Hello() != "freeseal|icsgo|" {
+		t.Errorf("got hello %q", tc.Hello())
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// TLSConfig configures the TLS connections to tls:// and wss:// addresses
+type TLSConfig struct {
+	// CAFile is a PEM bundle of the certificate authorities trusted to sign
+	// the server certificate; the system roots are used if it is empty
+	CAFile string
+	// ServerName is the name the server certificate is verified against; it
+	// defaults to the host of the address
+	ServerName string
+	// CertFile and KeyFile are the PEM encoded certificate and private key
+	// presented to servers that require client certificates
+	CertFile string
+	KeyFile  string
+	// PinnedSPKI are the base64 encoded SHA-256 hashes of the public keys
+	// (SubjectPublicKeyInfo) accepted for the server. If set, a certificate
+	// of the verified chain of the server, or the server certificate itself
+	// with InsecureSkipVerify, must have one of these keys
+	PinnedSPKI []string
+	// InsecureSkipVerify disables the verification of the certificate chain
+	// and server name. Combined with PinnedSPKI, the pinned keys alone
+	// authenticate the server, which suits self-signed certificates
+	InsecureSkipVerify bool
+}
+
+// build creates the crypto/tls configuration
+func (c *TLSConfig) build() (*tls.Config, error) {
+	cfg := &tls.Config{
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	if c.CAFile != "" {
+		pem, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read CA bundle")
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no certificates found in CA bundle %s", c.CAFile)
+		}
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load client certificate")
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(c.PinnedSPKI) > 0 {
+		pins := make(map[string]bool)
+		for _, pin := range c.PinnedSPKI {
+			if b, err := base64.StdEncoding.DecodeString(pin); err != nil || len(b) != sha256.Size {
+				return nil, errors.Errorf("invalid SPKI pin %q", pin)
+			}
+			pins[pin] = true
+		}
+		insecure := c.InsecureSkipVerify
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			return verifyPins(rawCerts, verifiedChains, insecure, pins)
+		}
+	} else if c.InsecureSkipVerify {
+		return nil, errors.New("InsecureSkipVerify requires PinnedSPKI")
+	}
+
+	return cfg, nil
+}
+
+// verifyPins checks that the server certificate has a pinned public key. It
+// runs after the certificate chain was verified, and then checks the
+// certificates of the verified chains only; the server can add any other
+// certificate to those it presents. If the chain is not verified, only the
+// certificate of the server itself is checked
+func verifyPins(rawCerts [][]byte, verifiedChains [][]*x509.Certificate, insecure bool, pins map[string]bool) error {
+	if insecure {
+		if len(rawCerts) == 0 {
+			return errors.New("server presented no certificate")
+		}
+		cert, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return errors.Wrap(err, "failed to parse server certificate")
+		}
+		if pins[SPKIHash(cert)] {
+			return nil
+		}
+	} else {
+		for _, chain := range verifiedChains {
+			for _, cert := range chain {
+				if pins[SPKIHash(cert)] {
+					return nil
+				}
+			}
+		}
+	}
+	return errors.New("server certificate does not match any pinned public key")
+}
+
+// SPKIHash returns the base64 encoded SHA-256 hash of the public key of a
+// certificate, as used by TLSConfig.PinnedSPKI
+func SPKIHash(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
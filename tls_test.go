@@ -0,0 +1,123 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+)
+
+// selfSigned creates a self-signed certificate for 127.0.0.1
+func selfSigned(t *testing.T, name string) ([]byte, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der, key
+}
+
+// serveTLS accepts TLS connections presenting the given certificates, the
+// first of which is the certificate of the server
+func serveTLS(t *testing.T, certs [][]byte, key *ecdsa.PrivateKey) net.Listener {
+	l, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: certs, PrivateKey: key}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			c.(*tls.Conn).Handshake()
+			c.Close()
+		}
+	}()
+	return l
+}
+
+func TestTLSPinnedSPKI(t *testing.T) {
+	server, key := selfSigned(t, "server")
+	other, _ := selfSigned(t, "other")
+	serverCert, _ := x509.ParseCertificate(server)
+	otherCert, _ := x509.ParseCertificate(other)
+
+	ca, err := ioutil.TempFile("", "icsgo-ca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(ca.Name())
+	pem.Encode(ca, &pem.Block{Type: "CERTIFICATE", Bytes: server})
+	ca.Close()
+
+	alone := serveTLS(t, [][]byte{server}, key)
+	defer alone.Close()
+	// a server appending a certificate with the pinned key to its own
+	appended := serveTLS(t, [][]byte{server, other}, key)
+	defer appended.Close()
+
+	tests := []struct {
+		name   string
+		l      net.Listener
+		config TLSConfig
+		ok     bool
+	}{
+		{"matching pin", alone, TLSConfig{PinnedSPKI: []string{SPKIHash(serverCert)}, InsecureSkipVerify: true}, true},
+		{"wrong pin", alone, TLSConfig{PinnedSPKI: []string{SPKIHash(otherCert)}, InsecureSkipVerify: true}, false},
+		{"appended certificate", appended, TLSConfig{PinnedSPKI: []string{SPKIHash(otherCert)}, InsecureSkipVerify: true}, false},
+		{"verified matching pin", appended, TLSConfig{CAFile: ca.Name(), PinnedSPKI: []string{SPKIHash(serverCert)}}, true},
+		{"verified appended certificate", appended, TLSConfig{CAFile: ca.Name(), PinnedSPKI: []string{SPKIHash(otherCert)}}, false},
+	}
+	for _, tt := range tests {
+		cfg, err := tt.config.build()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		u := &url.URL{Scheme: "tls", Host: tt.l.Addr().String()}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		conn, err := TLSTransport{Config: cfg}.Dial(ctx, u)
+		cancel()
+		if err == nil {
+			conn.Close()
+		}
+		if tt.ok && err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		if !tt.ok && err == nil {
+			t.Errorf("%s: the handshake succeeded", tt.name)
+		}
+	}
+}
@@ -0,0 +1,485 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+// Transport dials the stream of bytes a connection to the ICS server is
+// carried over. Telnet option negotiation, timeseal and prompt handling are
+// layered on top of the returned connection, whatever the transport
+type Transport interface {
+	Dial(ctx context.Context, addr *url.URL) (net.Conn, error)
+}
+
+// ConfigurableTransport is a transport that can dial with the Dialer, Proxy
+// and TLS settings of the client configuration. Clients dialing with these
+// settings fail over transports that do not implement it
+type ConfigurableTransport interface {
+	Transport
+	// Configure returns a copy of the transport dialing with the given
+	// function and TLS configuration, either of which can be nil to keep
+	// the setting of the transport
+	Configure(dial DialFunc, tc *tls.Config) Transport
+}
+
+var (
+	transportsMu sync.RWMutex
+	transports   = map[string]Transport{
+		"telnet": TCPTransport{},
+		"tls":    TLSTransport{},
+		"ws":     WebSocketTransport{},
+		"wss":    WebSocketTransport{},
+		"pipe":   PipeTransport{},
+	}
+)
+
+// RegisterTransport makes a transport available to Dial for addresses with
+// the given URL scheme, replacing any transport registered for it. Clients
+// configure it with their Dialer, Proxy and TLS settings if it implements
+// ConfigurableTransport
+func RegisterTransport(scheme string, t Transport) {
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+	transports[scheme] = t
+}
+
+// getTransport returns the transport registered for the given URL scheme
+func getTransport(scheme string) (Transport, error) {
+	transportsMu.RLock()
+	defer transportsMu.RUnlock()
+	t, ok := transports[scheme]
+	if !ok {
+		return nil, errors.Errorf("no transport registered for scheme %q", scheme)
+	}
+	return t, nil
+}
+
+// parseAddr parses the address of an ICS server. Addresses without a scheme,
+// such as freechess.org:5000, are telnet addresses
+func parseAddr(addr string) (*url.URL, error) {
+	if !strings.Contains(addr, "://") {
+		addr = "telnet://" + addr
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid server address %q", addr)
+	}
+	return u, nil
+}
+
+// dialConfig dials the server with the transport, TLS, proxy and rate limit
+// settings of the client configuration. Addresses without a scheme are dialed over TLS
+// if it is configured
+func dialConfig(ctx context.Context, cfg *Config, addr string, retries int) (*Conn, error) {
+	if cfg.TLS != nil && !strings.Contains(addr, "://") {
+		addr = "tls://" + addr
+	}
+	u, err := parseAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := getTransport(u.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	dial, err := configDialer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if dial != nil || cfg.TLS != nil {
+		var tc *tls.Config
+		if cfg.TLS != nil {
+			if tc, err = cfg.TLS.build(); err != nil {
+				return nil, err
+			}
+		}
+		ct, ok := transport.(ConfigurableTransport)
+		if !ok {
+			return nil, errors.Errorf("transport for scheme %q does not support the Dialer, Proxy and TLS settings", u.Scheme)
+		}
+		transport = ct.Configure(dial, tc)
+	}
+
+	timeout := time.Duration(cfg.ConnTimeout) * time.Second
+	conn, err := dialTransport(ctx, transport, u, retries, timeout, !cfg.DisableTimeseal, cfg.Debug)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetRateLimit(cfg.SendRate, cfg.SendBurst)
+	conn.SetPriorities(!cfg.DisablePriorities)
+	return conn, nil
+}
+
+// TCPTransport dials a plain TCP connection
+type TCPTransport struct {
+	// Dialer dials the connection, directly if it is nil
+	Dialer DialFunc
+}
+
+// Dial dials the host and port of the address over TCP
+func (t TCPTransport) Dial(ctx context.Context, addr *url.URL) (net.Conn, error) {
+	dial := t.Dialer
+	if dial == nil {
+		dial = dialDirect
+	}
+	return dial(ctx, "tcp", addr.Host)
+}
+
+// Configure returns a copy of the transport dialing with the given function;
+// TCP connections are not secured, so the TLS configuration is not used
+func (t TCPTransport) Configure(dial DialFunc, tc *tls.Config) Transport {
+	if dial != nil {
+		t.Dialer = dial
+	}
+	return t
+}
+
+// TLSTransport dials a TCP connection secured with TLS
+type TLSTransport struct {
+	// Config is the TLS configuration to use; if nil, the server certificate
+	// is verified against the system roots for the host of the address
+	Config *tls.Config
+	// Dialer dials the underlying TCP connection, directly if it is nil
+	Dialer DialFunc
+}
+
+// Dial dials the host and port of the address over TLS
+func (t TLSTransport) Dial(ctx context.Context, addr *url.URL) (net.Conn, error) {
+	cfg := tlsConfig(t.Config, addr)
+	nc, err := TCPTransport{Dialer: t.Dialer}.Dial(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	conn := tls.Client(nc, cfg)
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if err := conn.Handshake(); err != nil {
+		nc.Close()
+		return nil, errors.Wrap(err, "TLS handshake failed")
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// Configure returns a copy of the transport dialing with the given function
+// and TLS configuration
+func (t TLSTransport) Configure(dial DialFunc, tc *tls.Config) Transport {
+	if dial != nil {
+		t.Dialer = dial
+	}
+	if tc != nil {
+		t.Config = tc
+	}
+	return t
+}
+
+// tlsConfig returns a copy of the TLS configuration for the given address,
+// with the server name set from the address if it is not set
+func tlsConfig(cfg *tls.Config, addr *url.URL) *tls.Config {
+	if cfg == nil {
+		cfg = &tls.Config{}
+	}
+	cfg = cfg.Clone()
+	if cfg.ServerName == "" {
+		cfg.ServerName = addr.Hostname()
+	}
+	return cfg
+}
+
+// WebSocketTransport dials a WebSocket connection, ws:// or wss://, whose
+// binary messages carry the stream of bytes
+type WebSocketTransport struct {
+	// TLSConfig is the TLS configuration used for wss:// addresses
+	TLSConfig *tls.Config
+	// Dialer dials the underlying TCP connection, directly if it is nil
+	Dialer DialFunc
+}
+
+// Dial opens a WebSocket connection to the address
+func (t WebSocketTransport) Dial(ctx context.Context, addr *url.URL) (net.Conn, error) {
+	d := &websocket.Dialer{
+		NetDialContext: t.Dialer,
+	}
+	if addr.Scheme == "wss" {
+		d.TLSClientConfig = tlsConfig(t.TLSConfig, addr)
+	}
+	ws, _, err := d.DialContext(ctx, addr.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return newWebSocketConn(ws), nil
+}
+
+// Configure returns a copy of the transport dialing with the given function
+// and TLS configuration
+func (t WebSocketTransport) Configure(dial DialFunc, tc *tls.Config) Transport {
+	if dial != nil {
+		t.Dialer = dial
+	}
+	if tc != nil {
+		t.TLSConfig = tc
+	}
+	return t
+}
+
+// webSocketConn adapts a WebSocket connection to a net.Conn. Messages are
+// copied into a pipe as they arrive, so that reads can time out and be
+// retried, which a WebSocket connection does not allow
+type webSocketConn struct {
+	net.Conn
+	ws      *websocket.Conn
+	writeMu sync.Mutex
+}
+
+func newWebSocketConn(ws *websocket.Conn) net.Conn {
+	local, remote := net.Pipe()
+	go func() {
+		defer remote.Close()
+		for {
+			_, r, err := ws.NextReader()
+			if err != nil {
+				return
+			}
+			if _, err := io.Copy(remote, r); err != nil {
+				ws.Close()
+				return
+			}
+		}
+	}()
+	return &webSocketConn{Conn: local, ws: ws}
+}
+
+func (c *webSocketConn) Write(b []byte) (int, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.ws.WriteMessage(websocket.BinaryMessage, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+func (c *webSocketConn) Close() error {
+	c.Conn.Close()
+	return c.ws.Close()
+}
+
+func (c *webSocketConn) LocalAddr() net.Addr {
+	return c.ws.LocalAddr()
+}
+
+func (c *webSocketConn) RemoteAddr() net.Addr {
+	return c.ws.RemoteAddr()
+}
+
+func (c *webSocketConn) SetDeadline(t time.Time) error {
+	c.SetWriteDeadline(t)
+	return c.Conn.SetReadDeadline(t)
+}
+
+func (c *webSocketConn) SetWriteDeadline(t time.Time) error {
+	return c.ws.SetWriteDeadline(t)
+}
+
+var (
+	pipesMu sync.Mutex
+	pipes   = make(map[string]*PipeListener)
+)
+
+// PipeTransport connects to in-memory servers listening with ListenPipe, for
+// instance in tests; pipe://name addresses the server listening on name
+type PipeTransport struct{}
+
+// Dial connects to the in-memory server listening on the host of the address
+func (PipeTransport) Dial(ctx context.Context, addr *url.URL) (net.Conn, error) {
+	pipesMu.Lock()
+	l, ok := pipes[addr.Host]
+	pipesMu.Unlock()
+	if !ok {
+		return nil, errors.Errorf("no server listening on %s", addr)
+	}
+
+	client, server := bufferedPipe()
+	select {
+	case l.conns <- server:
+		return client, nil
+	case <-l.done:
+		return nil, errors.Errorf("no server listening on %s", addr)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// bufferedPipe creates an in-memory connection which, like a socket and
+// unlike net.Pipe, lets either end write without waiting for the other to read
+func bufferedPipe() (net.Conn, net.Conn) {
+	clientR, relayW := net.Pipe()
+	relayR, clientW := net.Pipe()
+	serverR, serverRelayW := net.Pipe()
+	serverRelayR, serverW := net.Pipe()
+	relay(serverRelayR, relayW)
+	relay(relayR, serverRelayW)
+	return &pipeConn{r: clientR, w: clientW}, &pipeConn{r: serverR, w: serverW}
+}
+
+// relay copies everything written to src into dst, buffering it so that
+// writes to src never wait for dst to be read
+func relay(src, dst net.Conn) {
+	var mu sync.Mutex
+	cond := sync.NewCond(&mu)
+	var buf []byte
+	var done bool
+
+	go func() {
+		b := make([]byte, 4096)
+		for {
+			n, err := src.Read(b)
+			mu.Lock()
+			buf = append(buf, b[:n]...)
+			done = err != nil
+			cond.Signal()
+			mu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer dst.Close()
+		for {
+			mu.Lock()
+			for len(buf) == 0 && !done {
+				cond.Wait()
+			}
+			b := buf
+			buf = nil
+			mu.Unlock()
+			if len(b) == 0 {
+				return
+			}
+			if _, err := dst.Write(b); err != nil {
+				src.Close()
+				return
+			}
+		}
+	}()
+}
+
+// pipeConn is one end of a buffered in-memory connection, reading from one
+// pipe and writing to another
+type pipeConn struct {
+	r net.Conn
+	w net.Conn
+}
+
+func (c *pipeConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+func (c *pipeConn) Write(b []byte) (int, error) {
+	return c.w.Write(b)
+}
+
+func (c *pipeConn) Close() error {
+	c.w.Close()
+	return c.r.Close()
+}
+
+func (c *pipeConn) LocalAddr() net.Addr {
+	return pipeAddr("local")
+}
+
+func (c *pipeConn) RemoteAddr() net.Addr {
+	return pipeAddr("remote")
+}
+
+func (c *pipeConn) SetDeadline(t time.Time) error {
+	c.w.SetWriteDeadline(t)
+	return c.r.SetReadDeadline(t)
+}
+
+func (c *pipeConn) SetReadDeadline(t time.Time) error {
+	return c.r.SetReadDeadline(t)
+}
+
+func (c *pipeConn) SetWriteDeadline(t time.Time) error {
+	return c.w.SetWriteDeadline(t)
+}
+
+// PipeListener accepts in-memory connections dialed with pipe:// addresses
+type PipeListener struct {
+	name  string
+	conns chan net.Conn
+	done  chan struct{}
+	once  sync.Once
+}
+
+// ListenPipe listens for in-memory connections to pipe://name
+func ListenPipe(name string) (*PipeListener, error) {
+	pipesMu.Lock()
+	defer pipesMu.Unlock()
+	if _, ok := pipes[name]; ok {
+		return nil, errors.Errorf("pipe %s is already in use", name)
+	}
+	l := &PipeListener{
+		name:  name,
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+	}
+	pipes[name] = l
+	return l, nil
+}
+
+// Accept waits for and returns the next connection to the listener
+func (l *PipeListener) Accept() (net.Conn, error) {
+	select {
+	case c := <-l.conns:
+		return c, nil
+	case <-l.done:
+		return nil, errors.New("pipe listener closed")
+	}
+}
+
+// Close stops listening
+func (l *PipeListener) Close() error {
+	l.once.Do(func() {
+		pipesMu.Lock()
+		delete(pipes, l.name)
+		pipesMu.Unlock()
+		close(l.done)
+	})
+	return nil
+}
+
+// Addr returns the address of the listener
+func (l *PipeListener) Addr() net.Addr {
+	return pipeAddr(l.name)
+}
+
+// pipeAddr is the address of an in-memory connection
+type pipeAddr string
+
+func (a pipeAddr) Network() string {
+	return "pipe"
+}
+
+func (a pipeAddr) String() string {
+	return "pipe://" + string(a)
+}
@@ -0,0 +1,79 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// recordingTransport dials in-memory servers with the dial function it was
+// configured with
+type recordingTransport struct {
+	dial DialFunc
+	tls  *tls.Config
+}
+
+func (t recordingTransport) Configure(dial DialFunc, tc *tls.Config) Transport {
+	return recordingTransport{dial: dial, tls: tc}
+}
+
+func (t recordingTransport) Dial(ctx context.Context, addr *url.URL) (net.Conn, error) {
+	if t.tls == nil || t.tls.ServerName != "example.org" {
+		return nil, net.UnknownNetworkError("TLS configuration not passed")
+	}
+	return t.dial(ctx, "pipe", addr.Host)
+}
+
+func TestDialConfigConfiguresRegisteredTransports(t *testing.T) {
+	l, err := ListenPipe("configured")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		if c, err := l.Accept(); err == nil {
+			defer c.Close()
+		}
+	}()
+
+	RegisterTransport("recording", recordingTransport{})
+	RegisterTransport("unconfigurable", PipeTransport{})
+	defer func() {
+		transportsMu.Lock()
+		delete(transports, "recording")
+		delete(transports, "unconfigurable")
+		transportsMu.Unlock()
+	}()
+
+	dialed := false
+	cfg := getConfig(&Config{
+		DisableTimeseal: true,
+		ConnRetries:     1,
+		TLS:             &TLSConfig{ServerName: "example.org"},
+		Dialer: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = true
+			return PipeTransport{}.Dial(ctx, &url.URL{Scheme: "pipe", Host: addr})
+		},
+	})
+	conn, err := dialConfig(context.Background(), cfg, "recording://configured", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	if !dialed {
+		t.Error("the registered transport did not dial with the configured dialer")
+	}
+
+	_, err = dialConfig(context.Background(), cfg, "unconfigurable://configured", 1)
+	if err == nil || !strings.Contains(err.Error(), "does not support") {
+		t.Errorf("got error %v, want the settings to be refused", err)
+	}
+}
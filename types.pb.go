@@ -150,6 +150,64 @@ func (x *PrivateTell) GetMessage() string {
 	return ""
 }
 
+// partner tell (bughouse)
+type PartnerTell struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// partner who made the tell
+	User string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// message / body of the tell
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *PartnerTell) Reset() {
+	*x = PartnerTell{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PartnerTell) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartnerTell) ProtoMessage() {}
+
+func (x *PartnerTell) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartnerTell.ProtoReflect.Descriptor instead.
+func (*PartnerTell) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PartnerTell) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *PartnerTell) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 // a game start message
 type GameStart struct {
 	state         protoimpl.MessageState
@@ -162,12 +220,14 @@ type GameStart struct {
 	PlayerOne string `protobuf:"bytes,2,opt,name=player_one,json=playerOne,proto3" json:"player_one,omitempty"`
 	// handle of player two
 	PlayerTwo string `protobuf:"bytes,3,opt,name=player_two,json=playerTwo,proto3" json:"player_two,omitempty"`
+	// details of the game, if the server sent them along (gameinfo)
+	Info *GameInfo `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
 }
 
 func (x *GameStart) Reset() {
 	*x = GameStart{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_types_proto_msgTypes[2]
+		mi := &file_types_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -180,7 +240,7 @@ func (x *GameStart) String() string {
 func (*GameStart) ProtoMessage() {}
 
 func (x *GameStart) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[2]
+	mi := &file_types_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -193,7 +253,7 @@ func (x *GameStart) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameStart.ProtoReflect.Descriptor instead.
 func (*GameStart) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{2}
+	return file_types_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GameStart) GetGameId() uint32 {
@@ -217,6 +277,13 @@ func (x *GameStart) GetPlayerTwo() string {
 	return ""
 }
 
+func (x *GameStart) GetInfo() *GameInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
 // a game end message
 type GameEnd struct {
 	state         protoimpl.MessageState
@@ -233,12 +300,18 @@ type GameEnd struct {
 	Reason uint32 `protobuf:"varint,4,opt,name=reason,proto3" json:"reason,omitempty"`
 	// message associated with the game result
 	Message string `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// result of the game in PGN notation: 1-0, 0-1, 1/2-1/2 or *
+	Result string `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"`
+	// outcome of the game: decisive, drawn, aborted or adjourned
+	Outcome uint32 `protobuf:"varint,7,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	// termination of the game, exactly as reported by the server
+	Termination string `protobuf:"bytes,8,opt,name=termination,proto3" json:"termination,omitempty"`
 }
 
 func (x *GameEnd) Reset() {
 	*x = GameEnd{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_types_proto_msgTypes[3]
+		mi := &file_types_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -251,7 +324,7 @@ func (x *GameEnd) String() string {
 func (*GameEnd) ProtoMessage() {}
 
 func (x *GameEnd) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[3]
+	mi := &file_types_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -264,7 +337,7 @@ func (x *GameEnd) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameEnd.ProtoReflect.Descriptor instead.
 func (*GameEnd) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{3}
+	return file_types_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GameEnd) GetGameId() uint32 {
@@ -302,12 +375,34 @@ func (x *GameEnd) GetMessage() string {
 	return ""
 }
 
+func (x *GameEnd) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *GameEnd) GetOutcome() uint32 {
+	if x != nil {
+		return x.Outcome
+	}
+	return 0
+}
+
+func (x *GameEnd) GetTermination() string {
+	if x != nil {
+		return x.Termination
+	}
+	return ""
+}
+
 // a game move message
 type GameMove struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// position in Forsyth-Edwards Notation
 	Fen       string `protobuf:"bytes,1,opt,name=fen,proto3" json:"fen,omitempty"`
 	Turn      string `protobuf:"bytes,2,opt,name=turn,proto3" json:"turn,omitempty"`
 	GameId    uint32 `protobuf:"varint,3,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
@@ -316,16 +411,46 @@ type GameMove struct {
 	Role      int32  `protobuf:"varint,6,opt,name=role,proto3" json:"role,omitempty"`
 	Time      uint32 `protobuf:"varint,7,opt,name=time,proto3" json:"time,omitempty"`
 	Inc       uint32 `protobuf:"varint,8,opt,name=inc,proto3" json:"inc,omitempty"`
-	WhiteTime uint32 `protobuf:"varint,9,opt,name=white_time,json=whiteTime,proto3" json:"white_time,omitempty"`
-	BlackTime uint32 `protobuf:"varint,10,opt,name=black_time,json=blackTime,proto3" json:"black_time,omitempty"`
+	// remaining time of both players, negative once a player has run out of time
+	WhiteTime int32  `protobuf:"zigzag32,9,opt,name=white_time,json=whiteTime,proto3" json:"white_time,omitempty"`
+	BlackTime int32  `protobuf:"zigzag32,10,opt,name=black_time,json=blackTime,proto3" json:"black_time,omitempty"`
 	MoveNo    uint32 `protobuf:"varint,11,opt,name=move_no,json=moveNo,proto3" json:"move_no,omitempty"`
 	Move      string `protobuf:"bytes,12,opt,name=move,proto3" json:"move,omitempty"`
+	// file (0-7) of a pawn that just moved two squares, -1 otherwise
+	DoublePawnPush int32 `protobuf:"varint,13,opt,name=double_pawn_push,json=doublePawnPush,proto3" json:"double_pawn_push,omitempty"`
+	// castling rights of both sides
+	WhiteCastleShort bool `protobuf:"varint,14,opt,name=white_castle_short,json=whiteCastleShort,proto3" json:"white_castle_short,omitempty"`
+	WhiteCastleLong  bool `protobuf:"varint,15,opt,name=white_castle_long,json=whiteCastleLong,proto3" json:"white_castle_long,omitempty"`
+	BlackCastleShort bool `protobuf:"varint,16,opt,name=black_castle_short,json=blackCastleShort,proto3" json:"black_castle_short,omitempty"`
+	BlackCastleLong  bool `protobuf:"varint,17,opt,name=black_castle_long,json=blackCastleLong,proto3" json:"black_castle_long,omitempty"`
+	// number of moves made since the last irreversible move
+	HalfmoveClock uint32 `protobuf:"varint,18,opt,name=halfmove_clock,json=halfmoveClock,proto3" json:"halfmove_clock,omitempty"`
+	// material strength of both sides
+	WhiteStrength uint32 `protobuf:"varint,19,opt,name=white_strength,json=whiteStrength,proto3" json:"white_strength,omitempty"`
+	BlackStrength uint32 `protobuf:"varint,20,opt,name=black_strength,json=blackStrength,proto3" json:"black_strength,omitempty"`
+	// the last move in verbose coordinate notation, e.g. K/e1-e2
+	VerboseMove string `protobuf:"bytes,21,opt,name=verbose_move,json=verboseMove,proto3" json:"verbose_move,omitempty"`
+	// time taken to make the last move, in milliseconds
+	MoveTime uint32 `protobuf:"varint,22,opt,name=move_time,json=moveTime,proto3" json:"move_time,omitempty"`
+	// whether the board should be displayed from black's perspective
+	Flip bool `protobuf:"varint,23,opt,name=flip,proto3" json:"flip,omitempty"`
+	// whether the clock of the side to move is running
+	ClockTicking bool `protobuf:"varint,24,opt,name=clock_ticking,json=clockTicking,proto3" json:"clock_ticking,omitempty"`
+	// lag incurred by the last move, in milliseconds
+	Lag uint32 `protobuf:"varint,25,opt,name=lag,proto3" json:"lag,omitempty"`
+	// whether white_time and black_time are in milliseconds rather than seconds
+	Ms bool `protobuf:"varint,26,opt,name=ms,proto3" json:"ms,omitempty"`
+	// whether the last move dropped a piece from the holdings, e.g. P@e4
+	Drop bool `protobuf:"varint,27,opt,name=drop,proto3" json:"drop,omitempty"`
+	// pieces in hand in crazyhouse and bughouse, uppercase for white and
+	// lowercase for black, as in the brackets of the FEN
+	Holdings string `protobuf:"bytes,28,opt,name=holdings,proto3" json:"holdings,omitempty"`
 }
 
 func (x *GameMove) Reset() {
 	*x = GameMove{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_types_proto_msgTypes[4]
+		mi := &file_types_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -338,7 +463,7 @@ func (x *GameMove) String() string {
 func (*GameMove) ProtoMessage() {}
 
 func (x *GameMove) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[4]
+	mi := &file_types_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -351,7 +476,7 @@ func (x *GameMove) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameMove.ProtoReflect.Descriptor instead.
 func (*GameMove) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{4}
+	return file_types_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GameMove) GetFen() string {
@@ -410,14 +535,14 @@ func (x *GameMove) GetInc() uint32 {
 	return 0
 }
 
-func (x *GameMove) GetWhiteTime() uint32 {
+func (x *GameMove) GetWhiteTime() int32 {
 	if x != nil {
 		return x.WhiteTime
 	}
 	return 0
 }
 
-func (x *GameMove) GetBlackTime() uint32 {
+func (x *GameMove) GetBlackTime() int32 {
 	if x != nil {
 		return x.BlackTime
 	}
@@ -438,6 +563,118 @@ func (x *GameMove) GetMove() string {
 	return ""
 }
 
+func (x *GameMove) GetDoublePawnPush() int32 {
+	if x != nil {
+		return x.DoublePawnPush
+	}
+	return 0
+}
+
+func (x *GameMove) GetWhiteCastleShort() bool {
+	if x != nil {
+		return x.WhiteCastleShort
+	}
+	return false
+}
+
+func (x *GameMove) GetWhiteCastleLong() bool {
+	if x != nil {
+		return x.WhiteCastleLong
+	}
+	return false
+}
+
+func (x *GameMove) GetBlackCastleShort() bool {
+	if x != nil {
+		return x.BlackCastleShort
+	}
+	return false
+}
+
+func (x *GameMove) GetBlackCastleLong() bool {
+	if x != nil {
+		return x.BlackCastleLong
+	}
+	return false
+}
+
+func (x *GameMove) GetHalfmoveClock() uint32 {
+	if x != nil {
+		return x.HalfmoveClock
+	}
+	return 0
+}
+
+func (x *GameMove) GetWhiteStrength() uint32 {
+	if x != nil {
+		return x.WhiteStrength
+	}
+	return 0
+}
+
+func (x *GameMove) GetBlackStrength() uint32 {
+	if x != nil {
+		return x.BlackStrength
+	}
+	return 0
+}
+
+func (x *GameMove) GetVerboseMove() string {
+	if x != nil {
+		return x.VerboseMove
+	}
+	return ""
+}
+
+func (x *GameMove) GetMoveTime() uint32 {
+	if x != nil {
+		return x.MoveTime
+	}
+	return 0
+}
+
+func (x *GameMove) GetFlip() bool {
+	if x != nil {
+		return x.Flip
+	}
+	return false
+}
+
+func (x *GameMove) GetClockTicking() bool {
+	if x != nil {
+		return x.ClockTicking
+	}
+	return false
+}
+
+func (x *GameMove) GetLag() uint32 {
+	if x != nil {
+		return x.Lag
+	}
+	return 0
+}
+
+func (x *GameMove) GetMs() bool {
+	if x != nil {
+		return x.Ms
+	}
+	return false
+}
+
+func (x *GameMove) GetDrop() bool {
+	if x != nil {
+		return x.Drop
+	}
+	return false
+}
+
+func (x *GameMove) GetHoldings() string {
+	if x != nil {
+		return x.Holdings
+	}
+	return ""
+}
+
 // a generic message from the server
 type Message struct {
 	state         protoimpl.MessageState
@@ -450,7 +687,7 @@ type Message struct {
 func (x *Message) Reset() {
 	*x = Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_types_proto_msgTypes[5]
+		mi := &file_types_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -463,7 +700,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_types_proto_msgTypes[5]
+	mi := &file_types_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -476,7 +713,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_types_proto_rawDescGZIP(), []int{5}
+	return file_types_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Message) GetMessage() string {
@@ -486,121 +723,1702 @@ func (x *Message) GetMessage() string {
 	return ""
 }
 
-var File_types_proto protoreflect.FileDescriptor
+// the connection to the server was lost
+type Disconnected struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_types_proto_rawDesc = []byte{
-	0x0a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x69,
-	0x63, 0x73, 0x67, 0x6f, 0x22, 0x55, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54,
-	0x65, 0x6c, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x12, 0x0a,
-	0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65,
-	0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3b, 0x0a, 0x0b, 0x50,
-	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6c, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73,
-	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x18,
-	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x62, 0x0a, 0x09, 0x47, 0x61, 0x6d, 0x65,
-	0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1d,
-	0x0a, 0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4f, 0x6e, 0x65, 0x12, 0x1d, 0x0a,
-	0x0a, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x74, 0x77, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x54, 0x77, 0x6f, 0x22, 0x82, 0x01, 0x0a,
-	0x07, 0x47, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49,
-	0x64, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x73,
-	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x73, 0x65, 0x72, 0x12,
-	0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x22, 0xac, 0x02, 0x0a, 0x08, 0x47, 0x61, 0x6d, 0x65, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x10,
-	0x0a, 0x03, 0x66, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x66, 0x65, 0x6e,
-	0x12, 0x12, 0x0a, 0x04, 0x74, 0x75, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x74, 0x75, 0x72, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a,
-	0x0a, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x77, 0x68, 0x69, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
-	0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72,
-	0x6f, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74,
-	0x69, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x6e, 0x63, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x03, 0x69, 0x6e, 0x63, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x74,
-	0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x77, 0x68, 0x69, 0x74, 0x65,
-	0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x74, 0x69,
-	0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x54,
-	0x69, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x6e, 0x6f, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x12, 0x12, 0x0a, 0x04,
-	0x6d, 0x6f, 0x76, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65,
-	0x22, 0x23, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x42, 0x09, 0x5a, 0x07, 0x2e, 0x2f, 0x69, 0x63, 0x73, 0x67, 0x6f,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	// reason the connection was lost
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-var (
-	file_types_proto_rawDescOnce sync.Once
-	file_types_proto_rawDescData = file_types_proto_rawDesc
-)
+func (x *Disconnected) Reset() {
+	*x = Disconnected{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_types_proto_rawDescGZIP() []byte {
-	file_types_proto_rawDescOnce.Do(func() {
-		file_types_proto_rawDescData = protoimpl.X.CompressGZIP(file_types_proto_rawDescData)
-	})
-	return file_types_proto_rawDescData
+func (x *Disconnected) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_types_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
-var file_types_proto_goTypes = []interface{}{
-	(*ChannelTell)(nil), // 0: icsgo.ChannelTell
-	(*PrivateTell)(nil), // 1: icsgo.PrivateTell
-	(*GameStart)(nil),   // 2: icsgo.GameStart
-	(*GameEnd)(nil),     // 3: icsgo.GameEnd
-	(*GameMove)(nil),    // 4: icsgo.GameMove
-	(*Message)(nil),     // 5: icsgo.Message
+func (*Disconnected) ProtoMessage() {}
+
+func (x *Disconnected) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_types_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+
+// Deprecated: Use Disconnected.ProtoReflect.Descriptor instead.
+func (*Disconnected) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{7}
 }
 
-func init() { file_types_proto_init() }
-func file_types_proto_init() {
-	if File_types_proto != nil {
-		return
+func (x *Disconnected) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_types_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChannelTell); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_types_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PrivateTell); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
+	return ""
+}
+
+// the connection to the server was restored
+type Reconnected struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// number of attempts it took to reconnect
+	Attempts uint32 `protobuf:"varint,1,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// handle the client is logged in as after reconnecting
+	Username string `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+func (x *Reconnected) Reset() {
+	*x = Reconnected{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Reconnected) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reconnected) ProtoMessage() {}
+
+func (x *Reconnected) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
-		file_types_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GameStart); i {
-			case 0:
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reconnected.ProtoReflect.Descriptor instead.
+func (*Reconnected) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Reconnected) GetAttempts() uint32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Reconnected) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// the reply to a command sent in block mode
+type Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// identifier the command was sent with
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// code identifying the command that was executed
+	Code uint32 `protobuf:"varint,2,opt,name=code,proto3" json:"code,omitempty"`
+	// output of the command
+	Body string `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+}
+
+func (x *Response) Reset() {
+	*x = Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Response) ProtoMessage() {}
+
+func (x *Response) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Response.ProtoReflect.Descriptor instead.
+func (*Response) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Response) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Response) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *Response) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+// a seek ad that was added to the seek list (seekinfo)
+type SeekAdd struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// index of the seek ad
+	Index uint32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// handle of the player seeking a game
+	Handle string `protobuf:"bytes,2,opt,name=handle,proto3" json:"handle,omitempty"`
+	// titles of the player, as a bitmask of title flags
+	Titles uint32 `protobuf:"varint,3,opt,name=titles,proto3" json:"titles,omitempty"`
+	// rating of the player
+	Rating uint32 `protobuf:"varint,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	// P for a provisional rating, E for an estimated one, empty otherwise
+	RatingFlag string `protobuf:"bytes,5,opt,name=rating_flag,json=ratingFlag,proto3" json:"rating_flag,omitempty"`
+	// initial time (in minutes) and increment (in seconds)
+	Time  uint32 `protobuf:"varint,6,opt,name=time,proto3" json:"time,omitempty"`
+	Inc   uint32 `protobuf:"varint,7,opt,name=inc,proto3" json:"inc,omitempty"`
+	Rated bool   `protobuf:"varint,8,opt,name=rated,proto3" json:"rated,omitempty"`
+	// type of game, e.g. blitz or crazyhouse
+	Type string `protobuf:"bytes,9,opt,name=type,proto3" json:"type,omitempty"`
+	// color requested by the player: W, B or ? for either
+	Color string `protobuf:"bytes,10,opt,name=color,proto3" json:"color,omitempty"`
+	// range of ratings of acceptable opponents
+	RatingMin uint32 `protobuf:"varint,11,opt,name=rating_min,json=ratingMin,proto3" json:"rating_min,omitempty"`
+	RatingMax uint32 `protobuf:"varint,12,opt,name=rating_max,json=ratingMax,proto3" json:"rating_max,omitempty"`
+	// whether the seek is accepted automatically, without a confirmation
+	Automatic bool `protobuf:"varint,13,opt,name=automatic,proto3" json:"automatic,omitempty"`
+	// whether the formula of opponents is checked
+	FormulaChecked bool `protobuf:"varint,14,opt,name=formula_checked,json=formulaChecked,proto3" json:"formula_checked,omitempty"`
+}
+
+func (x *SeekAdd) Reset() {
+	*x = SeekAdd{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeekAdd) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeekAdd) ProtoMessage() {}
+
+func (x *SeekAdd) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeekAdd.ProtoReflect.Descriptor instead.
+func (*SeekAdd) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SeekAdd) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *SeekAdd) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+func (x *SeekAdd) GetTitles() uint32 {
+	if x != nil {
+		return x.Titles
+	}
+	return 0
+}
+
+func (x *SeekAdd) GetRating() uint32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *SeekAdd) GetRatingFlag() string {
+	if x != nil {
+		return x.RatingFlag
+	}
+	return ""
+}
+
+func (x *SeekAdd) GetTime() uint32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *SeekAdd) GetInc() uint32 {
+	if x != nil {
+		return x.Inc
+	}
+	return 0
+}
+
+func (x *SeekAdd) GetRated() bool {
+	if x != nil {
+		return x.Rated
+	}
+	return false
+}
+
+func (x *SeekAdd) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SeekAdd) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *SeekAdd) GetRatingMin() uint32 {
+	if x != nil {
+		return x.RatingMin
+	}
+	return 0
+}
+
+func (x *SeekAdd) GetRatingMax() uint32 {
+	if x != nil {
+		return x.RatingMax
+	}
+	return 0
+}
+
+func (x *SeekAdd) GetAutomatic() bool {
+	if x != nil {
+		return x.Automatic
+	}
+	return false
+}
+
+func (x *SeekAdd) GetFormulaChecked() bool {
+	if x != nil {
+		return x.FormulaChecked
+	}
+	return false
+}
+
+// seek ads that were removed from the seek list (seekremove)
+type SeekRemove struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// indices of the removed seek ads
+	Indices []uint32 `protobuf:"varint,1,rep,packed,name=indices,proto3" json:"indices,omitempty"`
+}
+
+func (x *SeekRemove) Reset() {
+	*x = SeekRemove{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeekRemove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeekRemove) ProtoMessage() {}
+
+func (x *SeekRemove) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeekRemove.ProtoReflect.Descriptor instead.
+func (*SeekRemove) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SeekRemove) GetIndices() []uint32 {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+// the seek list was cleared (seekinfo)
+type SeekClear struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *SeekClear) Reset() {
+	*x = SeekClear{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeekClear) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeekClear) ProtoMessage() {}
+
+func (x *SeekClear) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeekClear.ProtoReflect.Descriptor instead.
+func (*SeekClear) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{12}
+}
+
+// an offer received from another player (pendinfo)
+type OfferReceived struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// identifier of the offer
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// handle of the player who made the offer
+	From string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// type of offer, e.g. match, draw, abort, adjourn, takeback or pause
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// parameters of the offer, such as the terms of a match request
+	Params string `protobuf:"bytes,4,opt,name=params,proto3" json:"params,omitempty"`
+}
+
+func (x *OfferReceived) Reset() {
+	*x = OfferReceived{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfferReceived) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferReceived) ProtoMessage() {}
+
+func (x *OfferReceived) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferReceived.ProtoReflect.Descriptor instead.
+func (*OfferReceived) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *OfferReceived) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OfferReceived) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *OfferReceived) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *OfferReceived) GetParams() string {
+	if x != nil {
+		return x.Params
+	}
+	return ""
+}
+
+// an offer sent to another player (pendinfo)
+type OfferSent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// identifier of the offer
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// handle of the player the offer was made to
+	To string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// type of offer, e.g. match, draw, abort, adjourn, takeback or pause
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// parameters of the offer, such as the terms of a match request
+	Params string `protobuf:"bytes,4,opt,name=params,proto3" json:"params,omitempty"`
+}
+
+func (x *OfferSent) Reset() {
+	*x = OfferSent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfferSent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferSent) ProtoMessage() {}
+
+func (x *OfferSent) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferSent.ProtoReflect.Descriptor instead.
+func (*OfferSent) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *OfferSent) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OfferSent) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *OfferSent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *OfferSent) GetParams() string {
+	if x != nil {
+		return x.Params
+	}
+	return ""
+}
+
+// an offer that was accepted, declined, withdrawn or otherwise removed (pendinfo)
+type OfferRemoved struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// identifier of the offer
+	Id uint32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *OfferRemoved) Reset() {
+	*x = OfferRemoved{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OfferRemoved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OfferRemoved) ProtoMessage() {}
+
+func (x *OfferRemoved) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OfferRemoved.ProtoReflect.Descriptor instead.
+func (*OfferRemoved) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OfferRemoved) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// details of a game (gameinfo)
+type GameInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id of the game
+	GameId uint32 `protobuf:"varint,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	// whether the game is private
+	Private bool `protobuf:"varint,2,opt,name=private,proto3" json:"private,omitempty"`
+	// type of game, e.g. blitz or crazyhouse
+	Type  string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Rated bool   `protobuf:"varint,4,opt,name=rated,proto3" json:"rated,omitempty"`
+	// whether the players are registered
+	WhiteRegistered bool `protobuf:"varint,5,opt,name=white_registered,json=whiteRegistered,proto3" json:"white_registered,omitempty"`
+	BlackRegistered bool `protobuf:"varint,6,opt,name=black_registered,json=blackRegistered,proto3" json:"black_registered,omitempty"`
+	// initial time of both players, in minutes
+	WhiteInitialTime uint32 `protobuf:"varint,7,opt,name=white_initial_time,json=whiteInitialTime,proto3" json:"white_initial_time,omitempty"`
+	BlackInitialTime uint32 `protobuf:"varint,8,opt,name=black_initial_time,json=blackInitialTime,proto3" json:"black_initial_time,omitempty"`
+	// increment of both players, in seconds
+	WhiteInc uint32 `protobuf:"varint,9,opt,name=white_inc,json=whiteInc,proto3" json:"white_inc,omitempty"`
+	BlackInc uint32 `protobuf:"varint,10,opt,name=black_inc,json=blackInc,proto3" json:"black_inc,omitempty"`
+	// id of the partner's game in bughouse, 0 otherwise
+	PartnerGame uint32 `protobuf:"varint,11,opt,name=partner_game,json=partnerGame,proto3" json:"partner_game,omitempty"`
+	// ratings of both players
+	WhiteRating uint32 `protobuf:"varint,12,opt,name=white_rating,json=whiteRating,proto3" json:"white_rating,omitempty"`
+	BlackRating uint32 `protobuf:"varint,13,opt,name=black_rating,json=blackRating,proto3" json:"black_rating,omitempty"`
+	// P for a provisional rating, E for an estimated one, empty otherwise
+	WhiteRatingFlag string `protobuf:"bytes,14,opt,name=white_rating_flag,json=whiteRatingFlag,proto3" json:"white_rating_flag,omitempty"`
+	BlackRatingFlag string `protobuf:"bytes,15,opt,name=black_rating_flag,json=blackRatingFlag,proto3" json:"black_rating_flag,omitempty"`
+	// whether the players are using timeseal
+	WhiteTimeseal bool `protobuf:"varint,16,opt,name=white_timeseal,json=whiteTimeseal,proto3" json:"white_timeseal,omitempty"`
+	BlackTimeseal bool `protobuf:"varint,17,opt,name=black_timeseal,json=blackTimeseal,proto3" json:"black_timeseal,omitempty"`
+}
+
+func (x *GameInfo) Reset() {
+	*x = GameInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameInfo) ProtoMessage() {}
+
+func (x *GameInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameInfo.ProtoReflect.Descriptor instead.
+func (*GameInfo) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GameInfo) GetGameId() uint32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *GameInfo) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *GameInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GameInfo) GetRated() bool {
+	if x != nil {
+		return x.Rated
+	}
+	return false
+}
+
+func (x *GameInfo) GetWhiteRegistered() bool {
+	if x != nil {
+		return x.WhiteRegistered
+	}
+	return false
+}
+
+func (x *GameInfo) GetBlackRegistered() bool {
+	if x != nil {
+		return x.BlackRegistered
+	}
+	return false
+}
+
+func (x *GameInfo) GetWhiteInitialTime() uint32 {
+	if x != nil {
+		return x.WhiteInitialTime
+	}
+	return 0
+}
+
+func (x *GameInfo) GetBlackInitialTime() uint32 {
+	if x != nil {
+		return x.BlackInitialTime
+	}
+	return 0
+}
+
+func (x *GameInfo) GetWhiteInc() uint32 {
+	if x != nil {
+		return x.WhiteInc
+	}
+	return 0
+}
+
+func (x *GameInfo) GetBlackInc() uint32 {
+	if x != nil {
+		return x.BlackInc
+	}
+	return 0
+}
+
+func (x *GameInfo) GetPartnerGame() uint32 {
+	if x != nil {
+		return x.PartnerGame
+	}
+	return 0
+}
+
+func (x *GameInfo) GetWhiteRating() uint32 {
+	if x != nil {
+		return x.WhiteRating
+	}
+	return 0
+}
+
+func (x *GameInfo) GetBlackRating() uint32 {
+	if x != nil {
+		return x.BlackRating
+	}
+	return 0
+}
+
+func (x *GameInfo) GetWhiteRatingFlag() string {
+	if x != nil {
+		return x.WhiteRatingFlag
+	}
+	return ""
+}
+
+func (x *GameInfo) GetBlackRatingFlag() string {
+	if x != nil {
+		return x.BlackRatingFlag
+	}
+	return ""
+}
+
+func (x *GameInfo) GetWhiteTimeseal() bool {
+	if x != nil {
+		return x.WhiteTimeseal
+	}
+	return false
+}
+
+func (x *GameInfo) GetBlackTimeseal() bool {
+	if x != nil {
+		return x.BlackTimeseal
+	}
+	return false
+}
+
+// pieces in hand of both players in crazyhouse and bughouse
+type Holdings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id of the game
+	GameId uint32 `protobuf:"varint,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	// pieces in hand of white, e.g. PNB
+	White string `protobuf:"bytes,2,opt,name=white,proto3" json:"white,omitempty"`
+	// pieces in hand of black, e.g. PQ
+	Black string `protobuf:"bytes,3,opt,name=black,proto3" json:"black,omitempty"`
+	// piece that was just passed or captured into the holdings, uppercase for
+	// white and lowercase for black, if any
+	AddedPiece string `protobuf:"bytes,4,opt,name=added_piece,json=addedPiece,proto3" json:"added_piece,omitempty"`
+}
+
+func (x *Holdings) Reset() {
+	*x = Holdings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Holdings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Holdings) ProtoMessage() {}
+
+func (x *Holdings) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Holdings.ProtoReflect.Descriptor instead.
+func (*Holdings) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Holdings) GetGameId() uint32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *Holdings) GetWhite() string {
+	if x != nil {
+		return x.White
+	}
+	return ""
+}
+
+func (x *Holdings) GetBlack() string {
+	if x != nil {
+		return x.Black
+	}
+	return ""
+}
+
+func (x *Holdings) GetAddedPiece() string {
+	if x != nil {
+		return x.AddedPiece
+	}
+	return ""
+}
+
+// the complete record of a game, as returned by the moves command
+type MoveList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id of the game, 0 for games that are not in progress
+	GameId uint32 `protobuf:"varint,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	// handles of the players
+	WhiteName string `protobuf:"bytes,2,opt,name=white_name,json=whiteName,proto3" json:"white_name,omitempty"`
+	BlackName string `protobuf:"bytes,3,opt,name=black_name,json=blackName,proto3" json:"black_name,omitempty"`
+	// ratings of the players, as reported by the server, e.g. 1500, UNR or ++++
+	WhiteRating string `protobuf:"bytes,4,opt,name=white_rating,json=whiteRating,proto3" json:"white_rating,omitempty"`
+	BlackRating string `protobuf:"bytes,5,opt,name=black_rating,json=blackRating,proto3" json:"black_rating,omitempty"`
+	// date and time the game started, as reported by the server
+	Date  string `protobuf:"bytes,6,opt,name=date,proto3" json:"date,omitempty"`
+	Rated bool   `protobuf:"varint,7,opt,name=rated,proto3" json:"rated,omitempty"`
+	// type of game, e.g. blitz or crazyhouse
+	Type string `protobuf:"bytes,8,opt,name=type,proto3" json:"type,omitempty"`
+	// initial time (in minutes) and increment (in seconds)
+	Time uint32 `protobuf:"varint,9,opt,name=time,proto3" json:"time,omitempty"`
+	Inc  uint32 `protobuf:"varint,10,opt,name=inc,proto3" json:"inc,omitempty"`
+	// moves of the game, alternating between white and black
+	Moves []*MoveList_Move `protobuf:"bytes,11,rep,name=moves,proto3" json:"moves,omitempty"`
+	// result of the game in PGN notation: 1-0, 0-1, 1/2-1/2 or *
+	Result string `protobuf:"bytes,12,opt,name=result,proto3" json:"result,omitempty"`
+	// termination of the game, e.g. Still in progress or Black resigns
+	Termination string `protobuf:"bytes,13,opt,name=termination,proto3" json:"termination,omitempty"`
+	// position the game started from in FEN, empty for the standard
+	// starting position
+	Fen string `protobuf:"bytes,14,opt,name=fen,proto3" json:"fen,omitempty"`
+}
+
+func (x *MoveList) Reset() {
+	*x = MoveList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveList) ProtoMessage() {}
+
+func (x *MoveList) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveList.ProtoReflect.Descriptor instead.
+func (*MoveList) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MoveList) GetGameId() uint32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *MoveList) GetWhiteName() string {
+	if x != nil {
+		return x.WhiteName
+	}
+	return ""
+}
+
+func (x *MoveList) GetBlackName() string {
+	if x != nil {
+		return x.BlackName
+	}
+	return ""
+}
+
+func (x *MoveList) GetWhiteRating() string {
+	if x != nil {
+		return x.WhiteRating
+	}
+	return ""
+}
+
+func (x *MoveList) GetBlackRating() string {
+	if x != nil {
+		return x.BlackRating
+	}
+	return ""
+}
+
+func (x *MoveList) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *MoveList) GetRated() bool {
+	if x != nil {
+		return x.Rated
+	}
+	return false
+}
+
+func (x *MoveList) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MoveList) GetTime() uint32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *MoveList) GetInc() uint32 {
+	if x != nil {
+		return x.Inc
+	}
+	return 0
+}
+
+func (x *MoveList) GetMoves() []*MoveList_Move {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *MoveList) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *MoveList) GetTermination() string {
+	if x != nil {
+		return x.Termination
+	}
+	return ""
+}
+
+func (x *MoveList) GetFen() string {
+	if x != nil {
+		return x.Fen
+	}
+	return ""
+}
+
+// a move sent in the compact format of the compressmove ivariable, which
+// clients expand into a GameMove from the previous position of the game
+type CompressedMove struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id of the game
+	GameId uint32 `protobuf:"varint,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	// number of half-moves played, including this one
+	Ply uint32 `protobuf:"varint,2,opt,name=ply,proto3" json:"ply,omitempty"`
+	// the move in standard algebraic notation
+	Move string `protobuf:"bytes,3,opt,name=move,proto3" json:"move,omitempty"`
+	// the move in Smith notation, e.g. e2e4 or e7e8Q
+	SmithMove string `protobuf:"bytes,4,opt,name=smith_move,json=smithMove,proto3" json:"smith_move,omitempty"`
+	// time taken to make the move, in milliseconds
+	MoveTime uint32 `protobuf:"varint,5,opt,name=move_time,json=moveTime,proto3" json:"move_time,omitempty"`
+	// clock of the player after the move, in milliseconds
+	TimeLeft int32 `protobuf:"varint,6,opt,name=time_left,json=timeLeft,proto3" json:"time_left,omitempty"`
+}
+
+func (x *CompressedMove) Reset() {
+	*x = CompressedMove{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompressedMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompressedMove) ProtoMessage() {}
+
+func (x *CompressedMove) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompressedMove.ProtoReflect.Descriptor instead.
+func (*CompressedMove) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CompressedMove) GetGameId() uint32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *CompressedMove) GetPly() uint32 {
+	if x != nil {
+		return x.Ply
+	}
+	return 0
+}
+
+func (x *CompressedMove) GetMove() string {
+	if x != nil {
+		return x.Move
+	}
+	return ""
+}
+
+func (x *CompressedMove) GetSmithMove() string {
+	if x != nil {
+		return x.SmithMove
+	}
+	return ""
+}
+
+func (x *CompressedMove) GetMoveTime() uint32 {
+	if x != nil {
+		return x.MoveTime
+	}
+	return 0
+}
+
+func (x *CompressedMove) GetTimeLeft() int32 {
+	if x != nil {
+		return x.TimeLeft
+	}
+	return 0
+}
+
+// the client stopped observing or examining a game, which goes on without it
+type GameUnobserved struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id of the game
+	GameId uint32 `protobuf:"varint,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+}
+
+func (x *GameUnobserved) Reset() {
+	*x = GameUnobserved{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GameUnobserved) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameUnobserved) ProtoMessage() {}
+
+func (x *GameUnobserved) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameUnobserved.ProtoReflect.Descriptor instead.
+func (*GameUnobserved) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GameUnobserved) GetGameId() uint32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+// a user logged in to the server (pin)
+type UserArrived struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// handle of the user
+	Handle string `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	// details sent with the notification, such as the titles and ratings of the user
+	Info string `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *UserArrived) Reset() {
+	*x = UserArrived{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserArrived) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserArrived) ProtoMessage() {}
+
+func (x *UserArrived) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserArrived.ProtoReflect.Descriptor instead.
+func (*UserArrived) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UserArrived) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+func (x *UserArrived) GetInfo() string {
+	if x != nil {
+		return x.Info
+	}
+	return ""
+}
+
+// a user logged out of the server (pin)
+type UserDeparted struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// handle of the user
+	Handle string `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"`
+}
+
+func (x *UserDeparted) Reset() {
+	*x = UserDeparted{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserDeparted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDeparted) ProtoMessage() {}
+
+func (x *UserDeparted) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDeparted.ProtoReflect.Descriptor instead.
+func (*UserDeparted) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UserDeparted) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+// a move of the game
+type MoveList_Move struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the move in standard algebraic notation
+	San string `protobuf:"bytes,1,opt,name=san,proto3" json:"san,omitempty"`
+	// time taken to make the move, in milliseconds
+	Time uint32 `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	// clock of the player after the move, in milliseconds, or 0 if it is
+	// not known and should be derived from the time taken
+	Clock uint32 `protobuf:"varint,3,opt,name=clock,proto3" json:"clock,omitempty"`
+}
+
+func (x *MoveList_Move) Reset() {
+	*x = MoveList_Move{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_types_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveList_Move) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveList_Move) ProtoMessage() {}
+
+func (x *MoveList_Move) ProtoReflect() protoreflect.Message {
+	mi := &file_types_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveList_Move.ProtoReflect.Descriptor instead.
+func (*MoveList_Move) Descriptor() ([]byte, []int) {
+	return file_types_proto_rawDescGZIP(), []int{18, 0}
+}
+
+func (x *MoveList_Move) GetSan() string {
+	if x != nil {
+		return x.San
+	}
+	return ""
+}
+
+func (x *MoveList_Move) GetTime() uint32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *MoveList_Move) GetClock() uint32 {
+	if x != nil {
+		return x.Clock
+	}
+	return 0
+}
+
+var File_types_proto protoreflect.FileDescriptor
+
+var file_types_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x69,
+	0x63, 0x73, 0x67, 0x6f, 0x22, 0x55, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54,
+	0x65, 0x6c, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x12, 0x0a,
+	0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65,
+	0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3b, 0x0a, 0x0b, 0x50,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x54, 0x65, 0x6c, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x3b, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x74,
+	0x6e, 0x65, 0x72, 0x54, 0x65, 0x6c, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x87, 0x01, 0x0a, 0x09, 0x47, 0x61, 0x6d, 0x65, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x4f, 0x6e, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
+	0x6c, 0x61, 0x79, 0x65, 0x72, 0x5f, 0x74, 0x77, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x70, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x54, 0x77, 0x6f, 0x12, 0x23, 0x0a, 0x04, 0x69, 0x6e,
+	0x66, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x69, 0x63, 0x73, 0x67, 0x6f,
+	0x2e, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22,
+	0xd6, 0x01, 0x0a, 0x07, 0x47, 0x61, 0x6d, 0x65, 0x45, 0x6e, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x67,
+	0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x67, 0x61,
+	0x6d, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x6f, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x73,
+	0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x6f, 0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6f,
+	0x75, 0x74, 0x63, 0x6f, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x65, 0x72,
+	0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xca, 0x06, 0x0a, 0x08, 0x47, 0x61, 0x6d,
+	0x65, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x66, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x75, 0x72, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x75, 0x72, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x67,
+	0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x67, 0x61,
+	0x6d, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x77, 0x68, 0x69, 0x74, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x4e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x6e,
+	0x63, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x69, 0x6e, 0x63, 0x12, 0x1d, 0x0a, 0x0a,
+	0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x11,
+	0x52, 0x09, 0x77, 0x68, 0x69, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x62,
+	0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x11, 0x52,
+	0x09, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x6f,
+	0x76, 0x65, 0x5f, 0x6e, 0x6f, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6d, 0x6f, 0x76,
+	0x65, 0x4e, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x64, 0x6f, 0x75, 0x62, 0x6c,
+	0x65, 0x5f, 0x70, 0x61, 0x77, 0x6e, 0x5f, 0x70, 0x75, 0x73, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0e, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x50, 0x61, 0x77, 0x6e, 0x50, 0x75, 0x73,
+	0x68, 0x12, 0x2c, 0x0a, 0x12, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x63, 0x61, 0x73, 0x74, 0x6c,
+	0x65, 0x5f, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x77,
+	0x68, 0x69, 0x74, 0x65, 0x43, 0x61, 0x73, 0x74, 0x6c, 0x65, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x12,
+	0x2a, 0x0a, 0x11, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x63, 0x61, 0x73, 0x74, 0x6c, 0x65, 0x5f,
+	0x6c, 0x6f, 0x6e, 0x67, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x77, 0x68, 0x69, 0x74,
+	0x65, 0x43, 0x61, 0x73, 0x74, 0x6c, 0x65, 0x4c, 0x6f, 0x6e, 0x67, 0x12, 0x2c, 0x0a, 0x12, 0x62,
+	0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x63, 0x61, 0x73, 0x74, 0x6c, 0x65, 0x5f, 0x73, 0x68, 0x6f, 0x72,
+	0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x43, 0x61,
+	0x73, 0x74, 0x6c, 0x65, 0x53, 0x68, 0x6f, 0x72, 0x74, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x6c, 0x61,
+	0x63, 0x6b, 0x5f, 0x63, 0x61, 0x73, 0x74, 0x6c, 0x65, 0x5f, 0x6c, 0x6f, 0x6e, 0x67, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x43, 0x61, 0x73, 0x74, 0x6c,
+	0x65, 0x4c, 0x6f, 0x6e, 0x67, 0x12, 0x25, 0x0a, 0x0e, 0x68, 0x61, 0x6c, 0x66, 0x6d, 0x6f, 0x76,
+	0x65, 0x5f, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x68,
+	0x61, 0x6c, 0x66, 0x6d, 0x6f, 0x76, 0x65, 0x43, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x25, 0x0a, 0x0e,
+	0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x77, 0x68, 0x69, 0x74, 0x65, 0x53, 0x74, 0x72, 0x65, 0x6e,
+	0x67, 0x74, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x73, 0x74, 0x72,
+	0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x62, 0x6c, 0x61,
+	0x63, 0x6b, 0x53, 0x74, 0x72, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x76, 0x65,
+	0x72, 0x62, 0x6f, 0x73, 0x65, 0x5f, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x73, 0x65, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x6c,
+	0x69, 0x70, 0x18, 0x17, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x6c, 0x69, 0x70, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x18,
+	0x18, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x63, 0x6b,
+	0x69, 0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x67, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x03, 0x6c, 0x61, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x6d, 0x73, 0x18, 0x1a, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x02, 0x6d, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x72, 0x6f, 0x70, 0x18, 0x1b, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x64, 0x72, 0x6f, 0x70, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x6c,
+	0x64, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x6c,
+	0x64, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x23, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x26, 0x0a, 0x0c, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x22, 0x45, 0x0a, 0x0b, 0x52, 0x65, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x08, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x42, 0x0a, 0x08, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0xf3, 0x02,
+	0x0a, 0x07, 0x53, 0x65, 0x65, 0x6b, 0x41, 0x64, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x61, 0x74, 0x69, 0x6e,
+	0x67, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x69, 0x6e, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x69, 0x6e, 0x63, 0x12, 0x14,
+	0x0a, 0x05, 0x72, 0x61, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72,
+	0x61, 0x74, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x6c, 0x6f,
+	0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x6c, 0x6f, 0x72, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x69, 0x6e, 0x12, 0x1d, 0x0a,
+	0x0a, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x61, 0x78, 0x12, 0x1c, 0x0a, 0x09,
+	0x61, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x61, 0x75, 0x74, 0x6f, 0x6d, 0x61, 0x74, 0x69, 0x63, 0x12, 0x27, 0x0a, 0x0f, 0x66, 0x6f,
+	0x72, 0x6d, 0x75, 0x6c, 0x61, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0e, 0x66, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x65, 0x64, 0x22, 0x26, 0x0a, 0x0a, 0x53, 0x65, 0x65, 0x6b, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0d, 0x52, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x22, 0x0b, 0x0a, 0x09, 0x53,
+	0x65, 0x65, 0x6b, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x22, 0x5f, 0x0a, 0x0d, 0x4f, 0x66, 0x66, 0x65,
+	0x72, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x22, 0x57, 0x0a, 0x09, 0x4f, 0x66, 0x66,
+	0x65, 0x72, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x22, 0x1e, 0x0a, 0x0c, 0x4f, 0x66, 0x66, 0x65, 0x72, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0xe2, 0x04, 0x0a, 0x08, 0x47, 0x61, 0x6d, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x76,
+	0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x61, 0x74, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10,
+	0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x77, 0x68, 0x69, 0x74, 0x65, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x62, 0x6c, 0x61, 0x63, 0x6b,
+	0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0f, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x65, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10,
+	0x77, 0x68, 0x69, 0x74, 0x65, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x2c, 0x0a, 0x12, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61,
+	0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x62, 0x6c,
+	0x61, 0x63, 0x6b, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x69, 0x6e, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x08, 0x77, 0x68, 0x69, 0x74, 0x65, 0x49, 0x6e, 0x63, 0x12, 0x1b, 0x0a, 0x09, 0x62,
+	0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x69, 0x6e, 0x63, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x62, 0x6c, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x63, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x74,
+	0x6e, 0x65, 0x72, 0x5f, 0x67, 0x61, 0x6d, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b,
+	0x70, 0x61, 0x72, 0x74, 0x6e, 0x65, 0x72, 0x47, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x77,
+	0x68, 0x69, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0b, 0x77, 0x68, 0x69, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x21,
+	0x0a, 0x0c, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x52, 0x61, 0x74, 0x69, 0x6e,
+	0x67, 0x12, 0x2a, 0x0a, 0x11, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6e,
+	0x67, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x77, 0x68,
+	0x69, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x2a, 0x0a,
+	0x11, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x66, 0x6c,
+	0x61, 0x67, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x52,
+	0x61, 0x74, 0x69, 0x6e, 0x67, 0x46, 0x6c, 0x61, 0x67, 0x12, 0x25, 0x0a, 0x0e, 0x77, 0x68, 0x69,
+	0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x65, 0x61, 0x6c, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0d, 0x77, 0x68, 0x69, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x65, 0x61, 0x6c,
+	0x12, 0x25, 0x0a, 0x0e, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x65,
+	0x61, 0x6c, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x65, 0x61, 0x6c, 0x22, 0x70, 0x0a, 0x08, 0x48, 0x6f, 0x6c, 0x64, 0x69,
+	0x6e, 0x67, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x77, 0x68, 0x69, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x77, 0x68, 0x69,
+	0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x64, 0x64, 0x65,
+	0x64, 0x5f, 0x70, 0x69, 0x65, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61,
+	0x64, 0x64, 0x65, 0x64, 0x50, 0x69, 0x65, 0x63, 0x65, 0x22, 0xc7, 0x03, 0x0a, 0x08, 0x4d, 0x6f,
+	0x76, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12,
+	0x1d, 0x0a, 0x0a, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x77, 0x68, 0x69, 0x74, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a,
+	0x0c, 0x77, 0x68, 0x69, 0x74, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x77, 0x68, 0x69, 0x74, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x52, 0x61, 0x74,
+	0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x61, 0x74, 0x65, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x6e, 0x63, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x03, 0x69, 0x6e, 0x63, 0x12, 0x2a, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x73,
+	0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x63, 0x73, 0x67, 0x6f, 0x2e, 0x4d,
+	0x6f, 0x76, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x52, 0x05, 0x6d, 0x6f,
+	0x76, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x74,
+	0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a,
+	0x03, 0x66, 0x65, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x66, 0x65, 0x6e, 0x1a,
+	0x42, 0x0a, 0x04, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x61, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x61, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6c,
+	0x6f, 0x63, 0x6b, 0x22, 0xa8, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x64, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x70, 0x6c, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x70, 0x6c,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6d, 0x69, 0x74, 0x68, 0x5f, 0x6d,
+	0x6f, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6d, 0x69, 0x74, 0x68,
+	0x4d, 0x6f, 0x76, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x6f, 0x76, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x65, 0x66, 0x74, 0x22, 0x29,
+	0x0a, 0x0e, 0x47, 0x61, 0x6d, 0x65, 0x55, 0x6e, 0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x67, 0x61, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x67, 0x61, 0x6d, 0x65, 0x49, 0x64, 0x22, 0x39, 0x0a, 0x0b, 0x55, 0x73, 0x65,
+	0x72, 0x41, 0x72, 0x72, 0x69, 0x76, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x6e, 0x64,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x69, 0x6e, 0x66, 0x6f, 0x22, 0x26, 0x0a, 0x0c, 0x55, 0x73, 0x65, 0x72, 0x44, 0x65, 0x70, 0x61,
+	0x72, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x42, 0x09, 0x5a, 0x07,
+	0x2e, 0x2f, 0x69, 0x63, 0x73, 0x67, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_types_proto_rawDescOnce sync.Once
+	file_types_proto_rawDescData = file_types_proto_rawDesc
+)
+
+func file_types_proto_rawDescGZIP() []byte {
+	file_types_proto_rawDescOnce.Do(func() {
+		file_types_proto_rawDescData = protoimpl.X.CompressGZIP(file_types_proto_rawDescData)
+	})
+	return file_types_proto_rawDescData
+}
+
+var file_types_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_types_proto_goTypes = []interface{}{
+	(*ChannelTell)(nil),    // 0: icsgo.ChannelTell
+	(*PrivateTell)(nil),    // 1: icsgo.PrivateTell
+	(*PartnerTell)(nil),    // 2: icsgo.PartnerTell
+	(*GameStart)(nil),      // 3: icsgo.GameStart
+	(*GameEnd)(nil),        // 4: icsgo.GameEnd
+	(*GameMove)(nil),       // 5: icsgo.GameMove
+	(*Message)(nil),        // 6: icsgo.Message
+	(*Disconnected)(nil),   // 7: icsgo.Disconnected
+	(*Reconnected)(nil),    // 8: icsgo.Reconnected
+	(*Response)(nil),       // 9: icsgo.Response
+	(*SeekAdd)(nil),        // 10: icsgo.SeekAdd
+	(*SeekRemove)(nil),     // 11: icsgo.SeekRemove
+	(*SeekClear)(nil),      // 12: icsgo.SeekClear
+	(*OfferReceived)(nil),  // 13: icsgo.OfferReceived
+	(*OfferSent)(nil),      // 14: icsgo.OfferSent
+	(*OfferRemoved)(nil),   // 15: icsgo.OfferRemoved
+	(*GameInfo)(nil),       // 16: icsgo.GameInfo
+	(*Holdings)(nil),       // 17: icsgo.Holdings
+	(*MoveList)(nil),       // 18: icsgo.MoveList
+	(*CompressedMove)(nil), // 19: icsgo.CompressedMove
+	(*GameUnobserved)(nil), // 20: icsgo.GameUnobserved
+	(*UserArrived)(nil),    // 21: icsgo.UserArrived
+	(*UserDeparted)(nil),   // 22: icsgo.UserDeparted
+	(*MoveList_Move)(nil),  // 23: icsgo.MoveList.Move
+}
+var file_types_proto_depIdxs = []int32{
+	16, // 0: icsgo.GameStart.info:type_name -> icsgo.GameInfo
+	23, // 1: icsgo.MoveList.moves:type_name -> icsgo.MoveList.Move
+	2,  // [2:2] is the sub-list for method output_type
+	2,  // [2:2] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_types_proto_init() }
+func file_types_proto_init() {
+	if File_types_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_types_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChannelTell); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrivateTell); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PartnerTell); i {
+			case 0:
 				return &v.state
 			case 1:
 				return &v.sizeCache
@@ -611,7 +2429,7 @@ func file_types_proto_init() {
 			}
 		}
 		file_types_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GameEnd); i {
+			switch v := v.(*GameStart); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -623,7 +2441,7 @@ func file_types_proto_init() {
 			}
 		}
 		file_types_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GameMove); i {
+			switch v := v.(*GameEnd); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -635,6 +2453,18 @@ func file_types_proto_init() {
 			}
 		}
 		file_types_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameMove); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Message); i {
 			case 0:
 				return &v.state
@@ -646,6 +2476,210 @@ func file_types_proto_init() {
 				return nil
 			}
 		}
+		file_types_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Disconnected); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Reconnected); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeekAdd); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeekRemove); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeekClear); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OfferReceived); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OfferSent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OfferRemoved); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Holdings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompressedMove); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GameUnobserved); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserArrived); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserDeparted); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_types_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveList_Move); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -653,7 +2687,7 @@ func file_types_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_types_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,316 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrQueueFull is returned when a message cannot be queued because too many
+// messages of the same priority are waiting to be written
+var ErrQueueFull = errors.New("write queue is full")
+
+// errConnClosed is returned for messages written after the connection closed
+var errConnClosed = errors.New("connection closed")
+
+// Priority is the lane a message is queued in before it is written to the
+// server. Messages are written in the order they were queued within a lane,
+// and lanes of higher priority are always emptied first. As every write
+// waits for its message to be written, the messages of a goroutine keep their
+// order; it is the messages of different goroutines waiting at the same time,
+// such as a tell and a move, that are reordered, unless priorities are
+// disabled with Conn.SetPriorities or Config.DisablePriorities
+type Priority int
+
+// priorities of outgoing messages, from the highest to the lowest
+const (
+	// acknowledgements of timeseal pings and raw messages, which are not rate limited
+	priorityAck Priority = iota
+	// moves, such as e4 or e7e8q
+	PriorityMove
+	// commands other than moves and chat
+	PriorityCommand
+	// tells, kibitzes, whispers and shouts
+	PriorityChat
+	numPriorities
+)
+
+// the number of messages that can wait in each lane
+const writeQueueSize = 64
+
+var moveRE *regexp.Regexp
+
+func init() {
+	// e4, Nbxd7, exd8=Q, e2e4, e7-e8q, P@f7, O-O-O
+	moveRE = regexp.MustCompile(`^(?:[NBRQK]?[a-h]?[1-8]?x?[a-h][1-8](?:=?[NBRQnbrq])?|[a-h][1-8]-?[a-h][1-8][nbrqk]?|[PNBRQ]@[a-h][1-8]|[oO0]-[oO0](?:-[oO0])?)[+#]?$`)
+}
+
+// chat commands, with the length of their shortest abbreviation
+var chatCommands = []struct {
+	name string
+	min  int
+}{
+	{"tell", 1},
+	{"xtell", 2},
+	{"ptell", 2},
+	{"say", 2},
+	{"kibitz", 2},
+	{"xkibitz", 2},
+	{"whisper", 2},
+	{"xwhisper", 3},
+	{"shout", 2},
+	{"cshout", 2},
+	{"it", 2},
+	{"message", 3},
+}
+
+// commandPriority returns the priority of a command sent to the server:
+// moves come first and chat last
+func commandPriority(cmd []byte) Priority {
+	fields := strings.Fields(string(cmd))
+	if len(fields) == 0 {
+		return PriorityCommand
+	}
+	if len(fields) == 1 && moveRE.MatchString(fields[0]) {
+		return PriorityMove
+	}
+
+	// shortcuts for tell, shout, cshout and it, such as .hi there
+	if strings.ContainsAny(fields[0][:1], ".,!^:") {
+		return PriorityChat
+	}
+	name := strings.ToLower(fields[0])
+	for _, c := range chatCommands {
+		if isCommand(name, c.name, c.min) {
+			return PriorityChat
+		}
+	}
+	return PriorityCommand
+}
+
+// outgoing is a message waiting to be written
+type outgoing struct {
+	msg []byte
+	// whether the message is written as it is, without timeseal encoding
+	raw bool
+	// receives the result of the write, if not nil
+	done chan error
+}
+
+// writeQueue holds the messages waiting to be written to the server by the
+// writer goroutine of a connection, and limits the rate they are written at
+// with a token bucket
+type writeQueue struct {
+	sync.Mutex
+	lanes  [numPriorities][]*outgoing
+	wake   chan struct{}
+	closed chan struct{}
+	once   sync.Once
+	// whether messages are written in the order they were queued, whatever
+	// their priority
+	fifo bool
+
+	// messages written per second, unlimited if zero
+	rate float64
+	// the number of messages that can be written at once
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newWriteQueue() *writeQueue {
+	return &writeQueue{
+		wake:   make(chan struct{}, 1),
+		closed: make(chan struct{}),
+	}
+}
+
+// push queues a message with the given priority
+func (q *writeQueue) push(p Priority, o *outgoing) error {
+	q.Lock()
+	defer q.Unlock()
+	select {
+	case <-q.closed:
+		return errConnClosed
+	default:
+	}
+	if q.fifo && p != priorityAck {
+		p = PriorityCommand
+	}
+	if len(q.lanes[p]) >= writeQueueSize {
+		return ErrQueueFull
+	}
+	q.lanes[p] = append(q.lanes[p], o)
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// pop removes the next message to be written from the queue. If the next
+// message has to wait for the rate limit, it returns how long to wait
+func (q *writeQueue) pop(now time.Time) (*outgoing, time.Duration) {
+	q.Lock()
+	defer q.Unlock()
+
+	if q.rate > 0 {
+		q.tokens += now.Sub(q.last).Seconds() * q.rate
+		if q.tokens > q.burst {
+			q.tokens = q.burst
+		}
+		q.last = now
+	}
+
+	for p := range q.lanes {
+		if len(q.lanes[p]) == 0 {
+			continue
+		}
+		if q.rate > 0 && Priority(p) != priorityAck {
+			if q.tokens < 1 {
+				return nil, time.Duration((1 - q.tokens) / q.rate * float64(time.Second))
+			}
+			q.tokens--
+		}
+		o := q.lanes[p][0]
+		q.lanes[p][0] = nil
+		q.lanes[p] = q.lanes[p][1:]
+		return o, 0
+	}
+	return nil, 0
+}
+
+// setRate changes the rate limit of the queue
+func (q *writeQueue) setRate(rate float64, burst int) {
+	q.Lock()
+	defer q.Unlock()
+	if burst < 1 {
+		burst = 1
+	}
+	q.rate = rate
+	q.burst = float64(burst)
+	q.tokens = q.burst
+	q.last = time.Now()
+}
+
+// setFIFO changes whether messages are written in the order they were queued
+func (q *writeQueue) setFIFO(fifo bool) {
+	q.Lock()
+	defer q.Unlock()
+	q.fifo = fifo
+}
+
+// close stops the queue, failing the messages still waiting in it
+func (q *writeQueue) close() {
+	q.once.Do(func() {
+		q.Lock()
+		defer q.Unlock()
+		close(q.closed)
+		for p := range q.lanes {
+			for _, o := range q.lanes[p] {
+				if o.done != nil {
+					o.done <- errConnClosed
+				}
+			}
+			q.lanes[p] = nil
+		}
+	})
+}
+
+// writeLoop writes the queued messages to the server, one at a time, until
+// the connection is closed
+func (c *Conn) writeLoop() {
+	q := c.queue
+	for {
+		o, wait := q.pop(time.Now())
+		if o != nil {
+			err := c.send(o)
+			if o.done != nil {
+				o.done <- err
+			}
+			continue
+		}
+
+		var timer *time.Timer
+		var expired <-chan time.Time
+		if wait > 0 {
+			timer = time.NewTimer(wait)
+			expired = timer.C
+		}
+		select {
+		case <-q.wake:
+		case <-expired:
+		case <-q.closed:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+
+		select {
+		case <-q.closed:
+			return
+		default:
+		}
+	}
+}
+
+// send writes a message to the server
+func (c *Conn) send(o *outgoing) error {
+	msg := o.msg
+	if !o.raw {
+		if c.debug {
+			log.Printf("> %s", string(msg))
+		}
+		if c.timeseal {
+			msg = encode(msg, len(msg))
+		}
+	}
+	c.conn.SetWriteDeadline(time.Now().Add(20 * time.Second))
+	_, err := c.conn.Conn.Write(msg)
+	return err
+}
+
+// enqueue queues a message, raw or timeseal-encoded, and, unless it is an
+// acknowledgement, waits for it to be written
+func (c *Conn) enqueue(p Priority, msg []byte, raw bool) error {
+	if p < priorityAck || p >= numPriorities {
+		return errors.Errorf("invalid priority %d", p)
+	}
+	o := &outgoing{
+		msg: append([]byte{}, msg...),
+		raw: raw,
+	}
+	if p != priorityAck || raw {
+		o.done = make(chan error, 1)
+	}
+	if err := c.queue.push(p, o); err != nil {
+		return err
+	}
+	if o.done == nil {
+		return nil
+	}
+	return <-o.done
+}
+
+// SetRateLimit limits the rate at which messages are written to the server,
+// to rate messages per second on average with bursts of up to burst
+// messages. A rate of zero or less removes the limit
+func (c *Conn) SetRateLimit(rate float64, burst int) {
+	c.queue.setRate(rate, burst)
+}
+
+// SetPriorities enables or disables the priorities of messages. Once they are
+// disabled, messages are written in the order they were queued
+func (c *Conn) SetPriorities(enabled bool) {
+	c.queue.setFIFO(!enabled)
+}
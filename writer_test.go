@@ -0,0 +1,135 @@
+// Copyright © 2019 Free Chess Club <hi@freechess.club>
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package icsgo
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dialWriter connects to an in-memory server, returning the lines it reads
+func dialWriter(t *testing.T, name string) (*Conn, <-chan string, func()) {
+	l, err := ListenPipe(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := make(chan string, 16)
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		r := bufio.NewReader(c)
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			lines <- strings.TrimSpace(line)
+		}
+	}()
+	conn, err := Dial("pipe://"+name, 1, time.Second, false, false)
+	if err != nil {
+		l.Close()
+		t.Fatal(err)
+	}
+	return conn, lines, func() {
+		conn.Close()
+		l.Close()
+	}
+}
+
+// queued waits until the given number of messages wait in the queue
+func queued(q *writeQueue, n int) {
+	for {
+		q.Lock()
+		total := 0
+		for _, lane := range q.lanes {
+			total += len(lane)
+		}
+		q.Unlock()
+		if total >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func readLines(t *testing.T, lines <-chan string, n int) []string {
+	var got []string
+	for i := 0; i < n; i++ {
+		select {
+		case line := <-lines:
+			got = append(got, line)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("read %v, want %d lines", got, n)
+		}
+	}
+	return got
+}
+
+func TestWriteKeepsTheOrderOfAGoroutine(t *testing.T) {
+	conn, lines, done := dialWriter(t, "writer-order")
+	defer done()
+	conn.SetRateLimit(20, 1)
+
+	for _, cmd := range []string{"tell GuestABCD hi", "match GuestABCD", "e4"} {
+		if err := conn.Write([]byte(cmd + "\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := strings.Join(readLines(t, lines, 3), ","); got != "tell GuestABCD hi,match GuestABCD,e4" {
+		t.Errorf("got %s", got)
+	}
+}
+
+func TestWritePriorities(t *testing.T) {
+	for _, tc := range []struct {
+		priorities bool
+		want       string
+	}{
+		{true, "match GuestABCD,e4,tell GuestABCD hi"},
+		{false, "match GuestABCD,tell GuestABCD hi,e4"},
+	} {
+		name := "writer-fifo"
+		if tc.priorities {
+			name = "writer-priorities"
+		}
+		conn, lines, done := dialWriter(t, name)
+		conn.SetRateLimit(20, 1)
+		conn.SetPriorities(tc.priorities)
+
+		// the first message takes the only token, so the others wait together
+		conn.Write([]byte("match GuestABCD\n"))
+		go conn.WritePriority([]byte("tell GuestABCD hi\n"), PriorityChat)
+		queued(conn.queue, 1)
+		go conn.WritePriority([]byte("e4\n"), PriorityMove)
+		queued(conn.queue, 2)
+
+		if got := strings.Join(readLines(t, lines, 3), ","); got != tc.want {
+			t.Errorf("priorities %t: got %s, want %s", tc.priorities, got, tc.want)
+		}
+		done()
+	}
+}
+
+func TestRawWriteSkipsTheQueuedMessages(t *testing.T) {
+	conn, lines, done := dialWriter(t, "writer-raw")
+	defer done()
+	conn.SetRateLimit(1, 1)
+
+	conn.Write([]byte("match GuestABCD\n"))
+	go conn.Write([]byte("tell GuestABCD hi\n"))
+	queued(conn.queue, 1)
+	if err := conn.RawWrite([]byte("raw\n")); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(readLines(t, lines, 2), ","); got != "match GuestABCD,raw" {
+		t.Errorf("got %s", got)
+	}
+}